@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+type ContextVariable string
+
+const ContextKeyClaims ContextVariable = "authClaims"
+
+type Claims map[string]interface{}
+
+func (c Claims) GetString(name string) string {
+	switch v := c[name].(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	}
+	return ""
+}
+
+func (c Claims) GetStringList(name string) []string {
+	switch v := c[name].(type) {
+	case string:
+		return strings.Fields(v)
+	case []interface{}:
+		res := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				res = append(res, s)
+			}
+		}
+		return res
+	}
+	return nil
+}
+
+func (c Claims) getTime(name string) (time.Time, bool) {
+	v, ok := c[name].(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	f, err := v.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(f), 0), true
+}
+
+func SetClaims(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, ContextKeyClaims, claims)
+}
+
+func GetClaims(ctx context.Context) Claims {
+	claims, ok := ctx.Value(ContextKeyClaims).(Claims)
+	if !ok {
+		return Claims{}
+	}
+	return claims
+}
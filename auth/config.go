@@ -0,0 +1,44 @@
+package auth
+
+import "time"
+
+type Config struct {
+	Issuer              string
+	Audience            []string
+	HS256Secret         string
+	JWKSURL             string
+	JWKSFile            string
+	JWKSRefreshInterval time.Duration
+	Leeway              time.Duration
+	CustomerIdClaim     string
+	AppUserIdClaim      string
+	EntityIdClaim       string
+	ScopeClaim          string
+	RoleClaim           string
+	// AllowMissingExpiry accepts tokens without an exp claim, they never expire
+	AllowMissingExpiry bool
+}
+
+const (
+	DefaultJWKSRefreshInterval = time.Hour
+	DefaultCustomerIdClaim     = "sub"
+	DefaultScopeClaim          = "scope"
+	DefaultRoleClaim           = "roles"
+)
+
+var MinJWKSRefreshInterval = time.Minute
+
+func (c *Config) setDefaults() {
+	if c.JWKSRefreshInterval <= 0 {
+		c.JWKSRefreshInterval = DefaultJWKSRefreshInterval
+	}
+	if c.CustomerIdClaim == "" {
+		c.CustomerIdClaim = DefaultCustomerIdClaim
+	}
+	if c.ScopeClaim == "" {
+		c.ScopeClaim = DefaultScopeClaim
+	}
+	if c.RoleClaim == "" {
+		c.RoleClaim = DefaultRoleClaim
+	}
+}
@@ -0,0 +1,29 @@
+package auth_test
+
+import (
+	"context"
+
+	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/log/logwriter"
+	"github.com/sabariramc/goserverbase/utils/testutils"
+)
+
+var AuthTestConfig *testutils.TestConfig
+var AuthTestLogger *log.Logger
+
+func init() {
+	testutils.Initialize()
+	AuthTestConfig = testutils.NewConfig()
+	consoleLogWriter := logwriter.NewConsoleWriter(log.HostParams{
+		Version:     AuthTestConfig.Logger.Version,
+		Host:        AuthTestConfig.App.Host,
+		ServiceName: AuthTestConfig.App.ServiceName,
+	})
+	lMux := log.NewDefaultLogMux(consoleLogWriter)
+	AuthTestLogger = log.NewLogger(context.TODO(), AuthTestConfig.Logger, "AuthTest", lMux, nil)
+}
+
+func GetCorrelationContext() context.Context {
+	ctx := context.WithValue(context.Background(), log.ContextKeyCorrelation, log.GetDefaultCorrelationParams(AuthTestConfig.App.ServiceName))
+	return ctx
+}
@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sabariramc/goserverbase/log"
+)
+
+var ErrKeyNotFound = fmt.Errorf("signing key not found")
+
+var ecAlgorithmCurve = map[string]string{
+	"ES256": "P-256",
+	"ES384": "P-384",
+	"ES512": "P-521",
+}
+
+type JSONWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg,omitempty"`
+	Use string `json:"use,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+type JWKS struct {
+	url             string
+	file            string
+	refreshInterval time.Duration
+	httpClient      *http.Client
+	log             *log.Logger
+	lock            sync.RWMutex
+	keys            map[string]crypto.PublicKey
+	lastRefresh     time.Time
+	lastAttempt     time.Time
+	refreshing      *jwksRefresh
+}
+
+type jwksRefresh struct {
+	done chan struct{}
+	err  error
+}
+
+func NewJWKS(ctx context.Context, logger *log.Logger, url, file string, refreshInterval time.Duration) (*JWKS, error) {
+	k := &JWKS{
+		url:             url,
+		file:            file,
+		refreshInterval: refreshInterval,
+		httpClient:      &http.Client{Timeout: time.Second * 10},
+		log:             logger,
+		keys:            make(map[string]crypto.PublicKey),
+	}
+	if err := k.Refresh(ctx); err != nil {
+		return nil, fmt.Errorf("auth.NewJWKS: %w", err)
+	}
+	return k, nil
+}
+
+// GetKey serves known keys from the cache and refreshes stale ones in the background; only unknown kids wait for a refresh.
+// Refresh attempts, successful or not, are spaced at least MinJWKSRefreshInterval apart and concurrent callers share one fetch.
+func (k *JWKS) GetKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	k.lock.RLock()
+	key, ok := k.keys[kid]
+	stale := time.Since(k.lastRefresh) >= k.refreshInterval
+	k.lock.RUnlock()
+	if ok {
+		if stale {
+			k.startRefresh(context.WithoutCancel(ctx), false)
+		}
+		return key, nil
+	}
+	call := k.startRefresh(context.WithoutCancel(ctx), false)
+	if call == nil {
+		return nil, fmt.Errorf("JWKS.GetKey: %w: %v", ErrKeyNotFound, kid)
+	}
+	if err := call.wait(ctx); err != nil {
+		return nil, fmt.Errorf("JWKS.GetKey: %w", err)
+	}
+	k.lock.RLock()
+	key, ok = k.keys[kid]
+	k.lock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("JWKS.GetKey: %w: %v", ErrKeyNotFound, kid)
+	}
+	return key, nil
+}
+
+func (k *JWKS) Refresh(ctx context.Context) error {
+	if err := k.startRefresh(context.WithoutCancel(ctx), true).wait(ctx); err != nil {
+		return fmt.Errorf("JWKS.Refresh: %w", err)
+	}
+	return nil
+}
+
+// startRefresh joins the refresh in flight or starts a new one, returns nil when the last attempt is too recent and force is not set
+func (k *JWKS) startRefresh(ctx context.Context, force bool) *jwksRefresh {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	if k.refreshing != nil {
+		return k.refreshing
+	}
+	if !force && time.Since(k.lastAttempt) < MinJWKSRefreshInterval {
+		return nil
+	}
+	call := &jwksRefresh{done: make(chan struct{})}
+	k.refreshing = call
+	k.lastAttempt = time.Now()
+	go func() {
+		call.err = k.refresh(ctx)
+		if call.err != nil {
+			k.log.Error(ctx, "JWKS refresh failed", call.err)
+		}
+		k.lock.Lock()
+		k.refreshing = nil
+		k.lock.Unlock()
+		close(call.done)
+	}()
+	return call
+}
+
+func (c *jwksRefresh) wait(ctx context.Context) error {
+	select {
+	case <-c.done:
+		return c.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (k *JWKS) refresh(ctx context.Context) error {
+	blob, err := k.load(ctx)
+	if err != nil {
+		return err
+	}
+	var set JSONWebKeySet
+	if err := json.Unmarshal(blob, &set); err != nil {
+		return err
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			k.log.Warning(ctx, "Skipping invalid JWK "+jwk.Kid, err)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	k.lock.Lock()
+	k.keys = keys
+	k.lastRefresh = time.Now()
+	k.lock.Unlock()
+	k.log.Info(ctx, "JWKS refreshed", map[string]any{"keyCount": len(keys)})
+	return nil
+}
+
+func (k *JWKS) load(ctx context.Context) ([]byte, error) {
+	if k.file != "" {
+		return os.ReadFile(k.file)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := k.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %v", res.StatusCode)
+	}
+	return io.ReadAll(res.Body)
+}
+
+func (j JSONWebKey) PublicKey() (crypto.PublicKey, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := decodeBigInt(j.N)
+		if err != nil {
+			return nil, fmt.Errorf("JSONWebKey.PublicKey: %w", err)
+		}
+		e, err := decodeBigInt(j.E)
+		if err != nil {
+			return nil, fmt.Errorf("JSONWebKey.PublicKey: %w", err)
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("JSONWebKey.PublicKey: unsupported curve %v", j.Crv)
+		}
+		if expected, ok := ecAlgorithmCurve[j.Alg]; ok && expected != j.Crv {
+			return nil, fmt.Errorf("JSONWebKey.PublicKey: curve %v does not match algorithm %v", j.Crv, j.Alg)
+		}
+		x, err := decodeBigInt(j.X)
+		if err != nil {
+			return nil, fmt.Errorf("JSONWebKey.PublicKey: %w", err)
+		}
+		y, err := decodeBigInt(j.Y)
+		if err != nil {
+			return nil, fmt.Errorf("JSONWebKey.PublicKey: %w", err)
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("JSONWebKey.PublicKey: point not on curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("JSONWebKey.PublicKey: unsupported key type %v", j.Kty)
+}
+
+func decodeBigInt(val string) (*big.Int, error) {
+	blob, err := base64.RawURLEncoding.DecodeString(val)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(blob), nil
+}
@@ -0,0 +1,197 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/sabariramc/goserverbase/log"
+)
+
+var (
+	ErrMalformedToken       = fmt.Errorf("malformed token")
+	ErrUnsupportedAlgorithm = fmt.Errorf("unsupported signing algorithm")
+	ErrInvalidSignature     = fmt.Errorf("invalid signature")
+	ErrTokenExpired         = fmt.Errorf("token expired")
+	ErrMissingExpiry        = fmt.Errorf("token has no expiry")
+	ErrTokenNotYetValid     = fmt.Errorf("token not yet valid")
+	ErrInvalidIssuer        = fmt.Errorf("invalid issuer")
+	ErrInvalidAudience      = fmt.Errorf("invalid audience")
+)
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+type Verifier struct {
+	c    *Config
+	jwks *JWKS
+	log  *log.Logger
+}
+
+func NewVerifier(ctx context.Context, logger *log.Logger, c Config) (*Verifier, error) {
+	c.setDefaults()
+	v := &Verifier{c: &c, log: logger}
+	if c.JWKSURL != "" || c.JWKSFile != "" {
+		jwks, err := NewJWKS(ctx, logger, c.JWKSURL, c.JWKSFile, c.JWKSRefreshInterval)
+		if err != nil {
+			logger.Error(ctx, "Error loading JWKS", err)
+			return nil, fmt.Errorf("auth.NewVerifier: %w", err)
+		}
+		v.jwks = jwks
+	}
+	return v, nil
+}
+
+func (v *Verifier) GetConfig() Config {
+	return *v.c
+}
+
+func (v *Verifier) Verify(ctx context.Context, token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("Verifier.Verify: %w", ErrMalformedToken)
+	}
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("Verifier.Verify: %w: %v", ErrMalformedToken, err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("Verifier.Verify: %w: %v", ErrMalformedToken, err)
+	}
+	if err := v.verifySignature(ctx, &h, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, fmt.Errorf("Verifier.Verify: %w", err)
+	}
+	claims := Claims{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("Verifier.Verify: %w: %v", ErrMalformedToken, err)
+	}
+	if err := v.validateClaims(claims); err != nil {
+		return nil, fmt.Errorf("Verifier.Verify: %w", err)
+	}
+	return claims, nil
+}
+
+func decodeSegment(segment string, dest interface{}) error {
+	blob, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(blob))
+	decoder.UseNumber()
+	return decoder.Decode(dest)
+}
+
+func (v *Verifier) verifySignature(ctx context.Context, h *header, signingInput, signature []byte) error {
+	digest := sha256.Sum256(signingInput)
+	switch h.Alg {
+	case "HS256":
+		if v.c.HS256Secret == "" {
+			return ErrUnsupportedAlgorithm
+		}
+		mac := hmac.New(sha256.New, []byte(v.c.HS256Secret))
+		mac.Write(signingInput)
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return ErrInvalidSignature
+		}
+		return nil
+	case "RS256":
+		key, err := v.getPublicKey(ctx, h.Kid)
+		if err != nil {
+			return err
+		}
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return ErrInvalidSignature
+		}
+		if rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest[:], signature) != nil {
+			return ErrInvalidSignature
+		}
+		return nil
+	case "ES256":
+		key, err := v.getPublicKey(ctx, h.Kid)
+		if err != nil {
+			return err
+		}
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok || ecKey.Curve != elliptic.P256() || len(signature) != 64 {
+			return ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(ecKey, digest[:], r, s) {
+			return ErrInvalidSignature
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: %v", ErrUnsupportedAlgorithm, h.Alg)
+}
+
+func (v *Verifier) getPublicKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	if v.jwks == nil {
+		return nil, ErrUnsupportedAlgorithm
+	}
+	return v.jwks.GetKey(ctx, kid)
+}
+
+func (v *Verifier) validateClaims(claims Claims) error {
+	now := time.Now()
+	exp, ok := claims.getTime("exp")
+	if !ok && !v.c.AllowMissingExpiry {
+		return ErrMissingExpiry
+	}
+	if ok && now.After(exp.Add(v.c.Leeway)) {
+		return ErrTokenExpired
+	}
+	if nbf, ok := claims.getTime("nbf"); ok && now.Add(v.c.Leeway).Before(nbf) {
+		return ErrTokenNotYetValid
+	}
+	if v.c.Issuer != "" && claims.GetString("iss") != v.c.Issuer {
+		return ErrInvalidIssuer
+	}
+	if len(v.c.Audience) > 0 {
+		audience := claims.GetStringList("aud")
+		for _, expected := range v.c.Audience {
+			for _, actual := range audience {
+				if expected == actual {
+					return nil
+				}
+			}
+		}
+		return ErrInvalidAudience
+	}
+	return nil
+}
+
+func (v *Verifier) GetCustomerIdentifier(claims Claims) *log.CustomerIdentifier {
+	identifier := &log.CustomerIdentifier{CustomerId: claims.GetString(v.c.CustomerIdClaim)}
+	if v.c.AppUserIdClaim != "" {
+		identifier.AppUserId = claims.GetString(v.c.AppUserIdClaim)
+	}
+	if v.c.EntityIdClaim != "" {
+		identifier.Id = claims.GetString(v.c.EntityIdClaim)
+	}
+	return identifier
+}
+
+func (v *Verifier) GetScopes(claims Claims) []string {
+	return claims.GetStringList(v.c.ScopeClaim)
+}
+
+func (v *Verifier) GetRoles(claims Claims) []string {
+	return claims.GetStringList(v.c.RoleClaim)
+}
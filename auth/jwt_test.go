@@ -0,0 +1,218 @@
+package auth_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sabariramc/goserverbase/auth"
+	"gotest.tools/assert"
+)
+
+func encodeSegment(val any) string {
+	blob, _ := json.Marshal(val)
+	return base64.RawURLEncoding.EncodeToString(blob)
+}
+
+func signHS256(secret string, claims map[string]any) string {
+	input := encodeSegment(map[string]any{"alg": "HS256", "typ": "JWT"}) + "." + encodeSegment(claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(input))
+	return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	input := encodeSegment(map[string]any{"alg": "RS256", "typ": "JWT", "kid": kid}) + "." + encodeSegment(claims)
+	digest := sha256.Sum256([]byte(input))
+	sig, _ := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func signES256(key *ecdsa.PrivateKey, kid string, claims map[string]any) string {
+	input := encodeSegment(map[string]any{"alg": "ES256", "typ": "JWT", "kid": kid}) + "." + encodeSegment(claims)
+	digest := sha256.Sum256([]byte(input))
+	r, s, _ := ecdsa.Sign(rand.Reader, key, digest[:])
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) auth.JSONWebKey {
+	return auth.JSONWebKey{
+		Kid: kid, Kty: "RSA", Use: "sig",
+		N: base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E: base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) auth.JSONWebKey {
+	return auth.JSONWebKey{
+		Kid: kid, Kty: "EC", Crv: key.Curve.Params().Name,
+		X: base64.RawURLEncoding.EncodeToString(key.X.Bytes()),
+		Y: base64.RawURLEncoding.EncodeToString(key.Y.Bytes()),
+	}
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub":   "customer_1",
+		"iss":   "https://issuer.test",
+		"aud":   []string{"api"},
+		"exp":   time.Now().Add(time.Minute).Unix(),
+		"nbf":   time.Now().Add(-time.Minute).Unix(),
+		"scope": "tenant:read tenant:write",
+	}
+}
+
+func TestVerifyHS256(t *testing.T) {
+	ctx := GetCorrelationContext()
+	v, err := auth.NewVerifier(ctx, AuthTestLogger, auth.Config{HS256Secret: "secret", Issuer: "https://issuer.test", Audience: []string{"api"}})
+	assert.NilError(t, err)
+	claims, err := v.Verify(ctx, signHS256("secret", validClaims()))
+	assert.NilError(t, err)
+	assert.Equal(t, v.GetCustomerIdentifier(claims).CustomerId, "customer_1")
+	assert.DeepEqual(t, v.GetScopes(claims), []string{"tenant:read", "tenant:write"})
+
+	_, err = v.Verify(ctx, signHS256("other", validClaims()))
+	assert.Assert(t, errors.Is(err, auth.ErrInvalidSignature))
+	claimSet := validClaims()
+	claimSet["exp"] = time.Now().Add(-time.Minute).Unix()
+	_, err = v.Verify(ctx, signHS256("secret", claimSet))
+	assert.Assert(t, errors.Is(err, auth.ErrTokenExpired))
+	claimSet = validClaims()
+	claimSet["nbf"] = time.Now().Add(time.Minute).Unix()
+	_, err = v.Verify(ctx, signHS256("secret", claimSet))
+	assert.Assert(t, errors.Is(err, auth.ErrTokenNotYetValid))
+	claimSet = validClaims()
+	delete(claimSet, "exp")
+	_, err = v.Verify(ctx, signHS256("secret", claimSet))
+	assert.Assert(t, errors.Is(err, auth.ErrMissingExpiry))
+	lenient, err := auth.NewVerifier(ctx, AuthTestLogger, auth.Config{HS256Secret: "secret", AllowMissingExpiry: true})
+	assert.NilError(t, err)
+	_, err = lenient.Verify(ctx, signHS256("secret", claimSet))
+	assert.NilError(t, err)
+	claimSet = validClaims()
+	claimSet["iss"] = "https://evil.test"
+	_, err = v.Verify(ctx, signHS256("secret", claimSet))
+	assert.Assert(t, errors.Is(err, auth.ErrInvalidIssuer))
+	claimSet = validClaims()
+	claimSet["aud"] = "web"
+	_, err = v.Verify(ctx, signHS256("secret", claimSet))
+	assert.Assert(t, errors.Is(err, auth.ErrInvalidAudience))
+	_, err = v.Verify(ctx, "abc.def")
+	assert.Assert(t, errors.Is(err, auth.ErrMalformedToken))
+}
+
+func TestVerifyRS256JWKSFile(t *testing.T) {
+	ctx := GetCorrelationContext()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NilError(t, err)
+	blob, _ := json.Marshal(auth.JSONWebKeySet{Keys: []auth.JSONWebKey{rsaJWK("rsa-1", &key.PublicKey)}})
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NilError(t, os.WriteFile(file, blob, 0600))
+	v, err := auth.NewVerifier(ctx, AuthTestLogger, auth.Config{JWKSFile: file, RoleClaim: "groups"})
+	assert.NilError(t, err)
+	claimSet := validClaims()
+	claimSet["groups"] = []string{"admin"}
+	claims, err := v.Verify(ctx, signRS256(key, "rsa-1", claimSet))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, v.GetRoles(claims), []string{"admin"})
+	_, err = v.Verify(ctx, signHS256("secret", validClaims()))
+	assert.Assert(t, errors.Is(err, auth.ErrUnsupportedAlgorithm))
+}
+
+func TestVerifyES256JWKSRotation(t *testing.T) {
+	ctx := GetCorrelationContext()
+	oldKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	newKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	var rotated atomic.Bool
+	var hits atomic.Int32
+	jwksServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		keys := []auth.JSONWebKey{ecJWK("ec-1", &oldKey.PublicKey)}
+		if rotated.Load() {
+			keys = []auth.JSONWebKey{ecJWK("ec-2", &newKey.PublicKey)}
+		}
+		json.NewEncoder(w).Encode(auth.JSONWebKeySet{Keys: keys})
+	}))
+	defer jwksServer.Close()
+	minRefresh := auth.MinJWKSRefreshInterval
+	auth.MinJWKSRefreshInterval = 0
+	defer func() { auth.MinJWKSRefreshInterval = minRefresh }()
+	v, err := auth.NewVerifier(ctx, AuthTestLogger, auth.Config{JWKSURL: jwksServer.URL})
+	assert.NilError(t, err)
+	_, err = v.Verify(ctx, signES256(oldKey, "ec-1", validClaims()))
+	assert.NilError(t, err)
+	assert.Equal(t, hits.Load(), int32(1))
+	rotated.Store(true)
+	_, err = v.Verify(ctx, signES256(newKey, "ec-2", validClaims()))
+	assert.NilError(t, err)
+	assert.Equal(t, hits.Load(), int32(2))
+	_, err = v.Verify(ctx, signES256(oldKey, "ec-3", validClaims()))
+	assert.Assert(t, errors.Is(err, auth.ErrKeyNotFound))
+}
+
+func TestJWKSOutageServesCachedKeys(t *testing.T) {
+	ctx := GetCorrelationContext()
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	var failing atomic.Bool
+	var hits atomic.Int32
+	gate := make(chan struct{})
+	jwksServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if failing.Load() {
+			<-gate
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(auth.JSONWebKeySet{Keys: []auth.JSONWebKey{ecJWK("ec-1", &key.PublicKey)}})
+	}))
+	defer jwksServer.Close()
+	releaseGate := sync.OnceFunc(func() { close(gate) })
+	defer releaseGate()
+	minRefresh := auth.MinJWKSRefreshInterval
+	auth.MinJWKSRefreshInterval = 0
+	defer func() { auth.MinJWKSRefreshInterval = minRefresh }()
+	v, err := auth.NewVerifier(ctx, AuthTestLogger, auth.Config{JWKSURL: jwksServer.URL, JWKSRefreshInterval: time.Nanosecond})
+	assert.NilError(t, err)
+	failing.Store(true)
+	for i := 0; i < 10; i++ {
+		_, err = v.Verify(ctx, signES256(key, "ec-1", validClaims()))
+		assert.NilError(t, err)
+	}
+	auth.MinJWKSRefreshInterval = time.Hour
+	releaseGate()
+	for i := 0; i < 3; i++ {
+		_, err = v.Verify(ctx, signES256(key, "ec-2", validClaims()))
+		assert.Assert(t, err != nil)
+	}
+	assert.Equal(t, hits.Load(), int32(2))
+}
+
+func TestJWKSCurveMatchesAlgorithm(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	jwk := ecJWK("ec-1", &key.PublicKey)
+	jwk.Alg = "ES256"
+	_, err := jwk.PublicKey()
+	assert.ErrorContains(t, err, "does not match algorithm")
+	jwk.Alg = "ES384"
+	_, err = jwk.PublicKey()
+	assert.NilError(t, err)
+}
@@ -32,7 +32,7 @@ func GetDefaultKMSClient(logger *log.Logger, keyArn string) *KMS {
 }
 
 func NewKMSClient(logger *log.Logger, client *kms.KMS, keyArn string) *KMS {
-	return &KMS{KMS: client, keyArn: &keyArn, log: logger}
+	return &KMS{KMS: client, keyArn: &keyArn, log: logger.NewResourceLogger("aws")}
 }
 
 func (k *KMS) EncryptWithContext(ctx context.Context, plainText *string) (cipherTextBlob []byte, b64EncodedText string, err error) {
@@ -0,0 +1,27 @@
+package aws
+
+import (
+	"strings"
+
+	"github.com/sabariramc/goserverbase/metrics"
+)
+
+const (
+	metricStatusSuccess = "success"
+	metricStatusFailure = "failure"
+)
+
+var sqsMessagesSent = metrics.NewCounterVec("sqs_messages_sent_total", "SQS messages sent", "queue", "status")
+var sqsMessagesReceived = metrics.NewCounterVec("sqs_messages_received_total", "SQS messages received", "queue")
+var sqsMessagesDeleted = metrics.NewCounterVec("sqs_messages_deleted_total", "SQS messages deleted", "queue", "status")
+
+func init() {
+	metrics.MustRegister(sqsMessagesSent, sqsMessagesReceived, sqsMessagesDeleted)
+}
+
+func getQueueName(queueURL *string) string {
+	if queueURL == nil {
+		return ""
+	}
+	return (*queueURL)[strings.LastIndex(*queueURL, "/")+1:]
+}
@@ -33,7 +33,7 @@ func GetDefaultS3Client(logger *log.Logger) *S3 {
 }
 
 func NewS3Client(client *s3.S3, logger *log.Logger) *S3 {
-	return &S3{S3: client, log: logger}
+	return &S3{S3: client, log: logger.NewResourceLogger("aws")}
 }
 
 func (s *S3) PutObjectWithContext(ctx context.Context, s3Bucket, s3Key string, body io.ReadSeeker, mimeType string) error {
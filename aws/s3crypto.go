@@ -63,7 +63,7 @@ func GetDefaultS3PIIClient(logger *log.Logger, keyArn string) (*S3PII, error) {
 }
 
 func NewS3PIIClient(encryptionClient *s3crypto.EncryptionClientV2, decryptionClient *s3crypto.DecryptionClient, s3Client *S3, logger *log.Logger) *S3PII {
-	return &S3PII{EncryptionClientV2: encryptionClient, DecryptionClient: decryptionClient, log: logger, S3: s3Client}
+	return &S3PII{EncryptionClientV2: encryptionClient, DecryptionClient: decryptionClient, log: logger.NewResourceLogger("aws"), S3: s3Client}
 }
 
 func (s *S3PII) PutObjectWithContext(ctx context.Context, s3Bucket, s3Key string, body io.ReadSeeker, mimeType string) error {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -14,7 +15,8 @@ import (
 type SecretManager struct {
 	_ struct{}
 	*secretsmanager.SecretsManager
-	log *log.Logger
+	log         *log.Logger
+	lastRefresh atomic.Int64
 }
 
 type secretManagerCache struct {
@@ -41,7 +43,7 @@ func GetDefaultSecretManagerClient(logger *log.Logger) *SecretManager {
 }
 
 func NewSecretManagerClient(logger *log.Logger, client *secretsmanager.SecretsManager) *SecretManager {
-	return &SecretManager{SecretsManager: client, log: logger}
+	return &SecretManager{SecretsManager: client, log: logger.NewResourceLogger("aws")}
 }
 
 func (s *SecretManager) GetSecret(ctx context.Context, secretArn string) (map[string]interface{}, error) {
@@ -56,12 +58,12 @@ func (s *SecretManager) GetSecret(ctx context.Context, secretArn string) (map[st
 		secretCacheData = secretManagerCache{expireTime: time.Now().Add(time.Minute * 15), data: *res}
 		secretCache[secretArn] = secretCacheData
 	}
-	s.log.Debug(ctx, "Secret data", secretCacheData)
+	s.log.Debug(ctx, "Secret data", map[string]any{"expireTime": secretCacheData.expireTime, "secretString": secretCacheData.data.SecretString})
 	data := make(map[string]interface{})
 	err := json.Unmarshal([]byte(*secretCacheData.data.SecretString), &data)
 	if err != nil {
 		s.log.Error(ctx, "Secret un-marshall error", err)
-		s.log.Debug(ctx, "Secret data", secretCacheData.data.SecretString)
+		s.log.Debug(ctx, "Secret data", map[string]any{"secretString": secretCacheData.data.SecretString})
 		return nil, fmt.Errorf("SecretManager.GetSecret: %w", err)
 	}
 	return data, nil
@@ -76,5 +78,19 @@ func (s *SecretManager) GetSecretValueWithContext(ctx context.Context, secretArn
 		return nil, fmt.Errorf("SecretManager.GetSecretNonCache: %w", err)
 	}
 	s.log.Debug(ctx, "Secret fetch response", res)
+	s.lastRefresh.Store(time.Now().UnixNano())
 	return res, nil
 }
+
+func (s *SecretManager) RefreshAgeCheck(maxAge time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		last := s.lastRefresh.Load()
+		if last == 0 {
+			return fmt.Errorf("SecretManager.RefreshAgeCheck: no successful secret refresh")
+		}
+		if age := time.Since(time.Unix(0, last)); age > maxAge {
+			return fmt.Errorf("SecretManager.RefreshAgeCheck: last successful refresh %v ago exceeds %v", age.Round(time.Second), maxAge)
+		}
+		return nil
+	}
+}
@@ -1,10 +1,22 @@
 package aws_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/sabariramc/goserverbase/aws"
+	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/log/logtest"
+	"gotest.tools/assert"
 )
 
 func TestSecretManager(t *testing.T) {
@@ -24,3 +36,31 @@ func TestSecretManager(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestSecretManagerDoesNotLogSecrets(t *testing.T) {
+	secrets := map[string]string{
+		"arn:test:json":  `{"dbPassword":"json-s3cr3t"}`,
+		"arn:test:plain": "plain-s3cr3t",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct{ SecretId string }
+		json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		json.NewEncoder(w).Encode(map[string]string{"ARN": req.SecretId, "Name": req.SecretId, "SecretString": secrets[req.SecretId]})
+	}))
+	defer server.Close()
+	sess, err := session.NewSession(&awssdk.Config{Endpoint: awssdk.String(server.URL), Region: awssdk.String("us-east-1"), Credentials: credentials.NewStaticCredentials("id", "secret", "")})
+	assert.NilError(t, err)
+	logger, capture := logtest.NewTestLogger(t)
+	client := aws.NewSecretManagerClient(logger, secretsmanager.New(sess))
+	ctx := context.Background()
+	secret, err := client.GetSecret(ctx, "arn:test:json")
+	assert.NilError(t, err)
+	assert.Equal(t, secret["dbPassword"], "json-s3cr3t")
+	_, err = client.GetSecret(ctx, "arn:test:plain")
+	assert.Assert(t, err != nil)
+	assert.Assert(t, len(capture.Find(log.DEBUG, "^Secret data$")) == 3)
+	for _, entry := range capture.Entries() {
+		assert.Assert(t, !strings.Contains(entry.FullMessage, "s3cr3t"), entry.FullMessage)
+	}
+}
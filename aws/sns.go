@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/trace"
 	"github.com/sabariramc/goserverbase/utils"
 )
 
@@ -33,17 +34,26 @@ func NewSNSClientWithSession(awsSession *session.Session) *sns.SNS {
 }
 
 func NewSNSClient(logger *log.Logger, client *sns.SNS) *SNS {
-	return &SNS{SNS: client, log: logger}
+	return &SNS{SNS: client, log: logger.NewResourceLogger("aws")}
 }
 
-func (s *SNS) PublishWithContext(ctx context.Context, topicArn, subject *string, payload *utils.Message, attributes map[string]string) error {
+func (s *SNS) PublishWithContext(ctx context.Context, topicArn, subject *string, payload *utils.Message, attributes map[string]string) (err error) {
+	topic := ""
+	if topicArn != nil {
+		topic = *topicArn
+	}
+	ctx, span := trace.Start(ctx, "publish "+topic, trace.SpanKindProducer, trace.String("messaging.system", "aws_sns"), trace.String("messaging.destination.name", topic))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
 	blob, _ := json.Marshal(payload)
 	message := string(blob)
 	req := &sns.PublishInput{
 		TopicArn:          topicArn,
 		Subject:           subject,
 		Message:           &message,
-		MessageAttributes: s.GetAttribute(attributes),
+		MessageAttributes: s.GetAttribute(injectTraceAttribute(ctx, attributes)),
 	}
 	s.log.Debug(ctx, "SNS publish request", req)
 	res, err := s.SNS.PublishWithContext(ctx, req)
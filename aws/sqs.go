@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/trace"
 	"github.com/sabariramc/goserverbase/utils"
 )
 
@@ -23,6 +24,9 @@ var defaultSQSClient *sqs.SQS
 var ErrTooManyMessageToDelete = fmt.Errorf("too many message in receiptHandlerMap(should be less that 10)")
 var DefaultMaxMessages int64 = 10
 
+// MaxMessageAttributes is the SQS/SNS limit on message attributes per message
+const MaxMessageAttributes = 10
+
 func GetDefaultSQSClient(logger *log.Logger, queueURL string) *SQS {
 	if defaultSecretManagerClient == nil {
 		defaultSQSClient = NewSQSClientWithSession(defaultAWSSession)
@@ -36,13 +40,24 @@ func NewSQSClientWithSession(awsSession *session.Session) *sqs.SQS {
 }
 
 func NewSQSClient(logger *log.Logger, client *sqs.SQS, queueURL string) *SQS {
-	return &SQS{SQS: client, queueURL: &queueURL, log: logger}
+	return &SQS{SQS: client, queueURL: &queueURL, log: logger.NewResourceLogger("aws")}
 }
 
 func (s *SQS) IsFIFO() bool {
 	return strings.HasSuffix(*s.queueURL, ".fifo")
 }
 
+func (s *SQS) HealthCheck(ctx context.Context) error {
+	_, err := s.SQS.GetQueueAttributesWithContext(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       s.queueURL,
+		AttributeNames: []*string{aws.String(sqs.QueueAttributeNameApproximateNumberOfMessages)},
+	})
+	if err != nil {
+		return fmt.Errorf("SQS.HealthCheck: %w", err)
+	}
+	return nil
+}
+
 func GetQueueUrlWithContext(ctx context.Context, logger *log.Logger, queueName string, sqsClient *sqs.SQS) (*string, error) {
 	req := &sqs.GetQueueUrlInput{
 		QueueName: &queueName}
@@ -57,12 +72,17 @@ func GetQueueUrlWithContext(ctx context.Context, logger *log.Logger, queueName s
 	return res.QueueUrl, nil
 }
 
-func (s *SQS) SendMessageWithContext(ctx context.Context, message *utils.Message, attribute map[string]string, delayInSeconds int64, messageDeduplicationId, messageGroupId *string) error {
+func (s *SQS) SendMessageWithContext(ctx context.Context, message *utils.Message, attribute map[string]string, delayInSeconds int64, messageDeduplicationId, messageGroupId *string) (err error) {
+	ctx, span := s.startSpan(ctx, "send", trace.SpanKindProducer)
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
 	body, err := utils.Serialize(message)
 	if err != nil {
 		return fmt.Errorf("SQS.SendMessage: %w", err)
 	}
-	messageAttributes := s.GetAttribute(attribute)
+	messageAttributes := s.GetAttribute(injectTraceAttribute(ctx, attribute))
 	req := &sqs.SendMessageInput{
 		QueueUrl:          s.queueURL,
 		DelaySeconds:      &delayInSeconds,
@@ -77,9 +97,11 @@ func (s *SQS) SendMessageWithContext(ctx context.Context, message *utils.Message
 	res, err := s.SQS.SendMessageWithContext(ctx, req)
 	s.log.Debug(ctx, "Queue send message response", res)
 	if err != nil {
+		sqsMessagesSent.WithLabelValues(getQueueName(s.queueURL), metricStatusFailure).Inc()
 		s.log.Error(ctx, "Error in sending message", err)
 		return fmt.Errorf("SQS.SendMessage: %w", err)
 	}
+	sqsMessagesSent.WithLabelValues(getQueueName(s.queueURL), metricStatusSuccess).Inc()
 	return nil
 }
 
@@ -91,7 +113,12 @@ type BatchQueueMessage struct {
 	MessageGroupId         *string
 }
 
-func (s *SQS) SendMessageBatchWithContext(ctx context.Context, messageList []*BatchQueueMessage, delayInSeconds int64) (*sqs.SendMessageBatchOutput, error) {
+func (s *SQS) SendMessageBatchWithContext(ctx context.Context, messageList []*BatchQueueMessage, delayInSeconds int64) (_ *sqs.SendMessageBatchOutput, err error) {
+	ctx, span := s.startSpan(ctx, "send", trace.SpanKindProducer, trace.Int("messaging.batch.message_count", len(messageList)))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
 	isFifo := s.IsFIFO()
 	messageReq := make([]*sqs.SendMessageBatchRequestEntry, len(messageList))
 	i := 0
@@ -103,7 +130,7 @@ func (s *SQS) SendMessageBatchWithContext(ctx context.Context, messageList []*Ba
 		m := &sqs.SendMessageBatchRequestEntry{
 			Id:                message.Id,
 			DelaySeconds:      &delayInSeconds,
-			MessageAttributes: s.GetAttribute(message.Attribute), MessageBody: body,
+			MessageAttributes: s.GetAttribute(injectTraceAttribute(ctx, message.Attribute)), MessageBody: body,
 		}
 		if isFifo {
 			m.MessageDeduplicationId = message.MessageDeduplicationId
@@ -118,9 +145,12 @@ func (s *SQS) SendMessageBatchWithContext(ctx context.Context, messageList []*Ba
 	}
 	res, err := s.SQS.SendMessageBatchWithContext(ctx, req)
 	if err != nil {
+		sqsMessagesSent.WithLabelValues(getQueueName(s.queueURL), metricStatusFailure).Add(float64(len(messageList)))
 		s.log.Error(ctx, "Error in batch send message", err)
 		return res, fmt.Errorf("SQS.SendMessageBatch : %w", err)
 	}
+	sqsMessagesSent.WithLabelValues(getQueueName(s.queueURL), metricStatusSuccess).Add(float64(len(res.Successful)))
+	sqsMessagesSent.WithLabelValues(getQueueName(s.queueURL), metricStatusFailure).Add(float64(len(res.Failed)))
 	s.log.Debug(ctx, "Queue send message batch message", res)
 	return res, nil
 }
@@ -139,7 +169,12 @@ func (s *SQS) GetAttribute(attribute map[string]string) map[string]*sqs.MessageA
 	return messageAttributes
 }
 
-func (s *SQS) ReceiveMessageWithContext(ctx context.Context, timeoutInSeconds int64, maxNumberOfMessages int64, waitTimeInSeconds int64) ([]*sqs.Message, error) {
+func (s *SQS) ReceiveMessageWithContext(ctx context.Context, timeoutInSeconds int64, maxNumberOfMessages int64, waitTimeInSeconds int64) (_ []*sqs.Message, err error) {
+	ctx, span := s.startSpan(ctx, "receive", trace.SpanKindConsumer)
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
 	req := &sqs.ReceiveMessageInput{
 		AttributeNames: []*string{
 			aws.String(sqs.MessageSystemAttributeNameSentTimestamp),
@@ -159,11 +194,17 @@ func (s *SQS) ReceiveMessageWithContext(ctx context.Context, timeoutInSeconds in
 		return nil, fmt.Errorf("SQS.ReceiveMessage: %w", err)
 	}
 	s.log.Debug(ctx, "Queue receive response", msgResult)
+	sqsMessagesReceived.WithLabelValues(getQueueName(s.queueURL)).Add(float64(len(msgResult.Messages)))
 
 	return msgResult.Messages, nil
 }
 
-func (s *SQS) DeleteMessageWithContext(ctx context.Context, receiptHandler *string) error {
+func (s *SQS) DeleteMessageWithContext(ctx context.Context, receiptHandler *string) (err error) {
+	ctx, span := s.startSpan(ctx, "delete", trace.SpanKindClient)
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
 	req := &sqs.DeleteMessageInput{
 		QueueUrl:      s.queueURL,
 		ReceiptHandle: receiptHandler,
@@ -171,14 +212,21 @@ func (s *SQS) DeleteMessageWithContext(ctx context.Context, receiptHandler *stri
 	s.log.Debug(ctx, "Queue delete request", req)
 	res, err := s.SQS.DeleteMessageWithContext(ctx, req)
 	if err != nil {
+		sqsMessagesDeleted.WithLabelValues(getQueueName(s.queueURL), metricStatusFailure).Inc()
 		s.log.Error(ctx, "Error in delete message", err)
 		return fmt.Errorf("SQS.DeleteMessage: %w", err)
 	}
+	sqsMessagesDeleted.WithLabelValues(getQueueName(s.queueURL), metricStatusSuccess).Inc()
 	s.log.Debug(ctx, "Queue delete response", res)
 	return nil
 }
 
-func (s *SQS) DeleteMessageBatchWithContext(ctx context.Context, receiptHandlerMap map[string]*string) (*sqs.DeleteMessageBatchOutput, error) {
+func (s *SQS) DeleteMessageBatchWithContext(ctx context.Context, receiptHandlerMap map[string]*string) (_ *sqs.DeleteMessageBatchOutput, err error) {
+	ctx, span := s.startSpan(ctx, "delete", trace.SpanKindClient, trace.Int("messaging.batch.message_count", len(receiptHandlerMap)))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
 	if len(receiptHandlerMap) > 10 {
 		return nil, fmt.Errorf("SQS.DeleteMessage: %w", ErrTooManyMessageToDelete)
 	}
@@ -199,9 +247,46 @@ func (s *SQS) DeleteMessageBatchWithContext(ctx context.Context, receiptHandlerM
 	s.log.Debug(ctx, "Queue delete batch request", req)
 	res, err := s.SQS.DeleteMessageBatchWithContext(ctx, req)
 	if err != nil {
+		sqsMessagesDeleted.WithLabelValues(getQueueName(s.queueURL), metricStatusFailure).Add(float64(len(entries)))
 		s.log.Error(ctx, "Error in delete batch message", err)
 		return nil, fmt.Errorf("SQS.DeleteMessage: %w", err)
 	}
+	sqsMessagesDeleted.WithLabelValues(getQueueName(s.queueURL), metricStatusSuccess).Add(float64(len(res.Successful)))
+	sqsMessagesDeleted.WithLabelValues(getQueueName(s.queueURL), metricStatusFailure).Add(float64(len(res.Failed)))
 	s.log.Debug(ctx, "Queue delete batch response", res)
 	return res, nil
 }
+
+func (s *SQS) startSpan(ctx context.Context, operation string, kind trace.SpanKind, attributes ...trace.Attribute) (context.Context, *trace.Span) {
+	queueName := getQueueName(s.queueURL)
+	attributes = append(attributes, trace.String("messaging.system", "aws_sqs"), trace.String("messaging.destination.name", queueName), trace.String("messaging.operation", operation))
+	return trace.Start(ctx, operation+" "+queueName, kind, attributes...)
+}
+
+// injectTraceAttribute adds the trace headers only while the message stays within MaxMessageAttributes, traceparent takes precedence over tracestate
+func injectTraceAttribute(ctx context.Context, attribute map[string]string) map[string]string {
+	carrier := make(trace.MapCarrier, 2)
+	trace.Inject(ctx, carrier)
+	res := make(map[string]string, len(attribute)+len(carrier))
+	for key, value := range attribute {
+		res[key] = value
+	}
+	for _, key := range []string{trace.HeaderTraceParent, trace.HeaderTraceState} {
+		value, ok := carrier[key]
+		if !ok || len(res) >= MaxMessageAttributes {
+			continue
+		}
+		res[key] = value
+	}
+	return res
+}
+
+func GetMessageContext(ctx context.Context, msg *sqs.Message) context.Context {
+	carrier := make(trace.MapCarrier, 2)
+	for _, key := range []string{trace.HeaderTraceParent, trace.HeaderTraceState} {
+		if attr, ok := msg.MessageAttributes[key]; ok && attr.StringValue != nil {
+			carrier[key] = *attr.StringValue
+		}
+	}
+	return trace.Extract(ctx, carrier)
+}
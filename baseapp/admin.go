@@ -0,0 +1,26 @@
+package baseapp
+
+import (
+	"net/http"
+
+	"github.com/sabariramc/goserverbase/errors"
+)
+
+func (b *BaseApp) SetAdminMiddleware(middlewares ...func(http.Handler) http.Handler) {
+	b.adminMiddlewares = middlewares
+}
+
+func (b *BaseApp) AdminMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(b.adminMiddlewares) == 0 {
+			b.log.Warning(r.Context(), "Admin route called without an admin guard configured", r.URL.Path)
+			b.SendErrorResponse(r.Context(), w, "", errors.NewHTTPClientError(http.StatusForbidden, "ADMIN_GUARD_NOT_CONFIGURED", "Admin routes are disabled until an admin guard is configured", nil, nil))
+			return
+		}
+		handler := next
+		for i := len(b.adminMiddlewares) - 1; i >= 0; i-- {
+			handler = b.adminMiddlewares[i](handler)
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,177 @@
+package baseapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	e "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sabariramc/goserverbase/errors"
+	"github.com/sabariramc/goserverbase/log"
+)
+
+const AuditEventHTTPRequest = "http.request"
+
+type HTTPAuditEvent struct {
+	Type       string            `json:"type" bson:"type"`
+	Method     string            `json:"method" bson:"method"`
+	Route      string            `json:"route" bson:"route"`
+	Path       string            `json:"path" bson:"path"`
+	PathParams map[string]string `json:"pathParams" bson:"pathParams"`
+	Body       any               `json:"body,omitempty" bson:"body,omitempty"`
+	StatusCode int               `json:"statusCode" bson:"statusCode"`
+	LatencyMs  int64             `json:"latencyMs" bson:"latencyMs"`
+}
+
+var auditMethods = map[string]bool{
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// AuditMiddleware writes an HTTPAuditEvent wrapped in a log.AuditRecord for mutating requests, the body is always redacted as audit records outlive the log retention
+func (b *BaseApp) AuditMiddleware(next http.Handler) http.Handler {
+	lConfig := *b.lConfig
+	lConfig.DisableRedaction = false
+	redactor := log.NewDefaultRedactor(&lConfig)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !auditMethods[r.Method] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		st := time.Now()
+		ctx := r.Context()
+		body, err := readAuditBody(r, redactor)
+		if err != nil {
+			b.log.Notice(ctx, "Audit request body read failed", err)
+			var maxBytesErr *http.MaxBytesError
+			if !e.As(err, &maxBytesErr) {
+				err = errors.NewHTTPClientError(http.StatusBadRequest, "INVALID_REQUEST_BODY", "Request body could not be read", nil, map[string]any{"error": err.Error()})
+			}
+			b.SendErrorResponse(ctx, w, "", fmt.Errorf("BaseApp.AuditMiddleware: %w", err))
+			return
+		}
+		var handlerErr error
+		ctx = context.WithValue(ctx, ContextKeyError, func(err error) {
+			handlerErr = err
+			b.SetHandlerError(r.Context(), err)
+		})
+		statusRW := &statusResponseWriter{ResponseWriter: w}
+		auditReq := r.WithContext(ctx)
+		defer func() {
+			rec := recover()
+			status := statusRW.status
+			switch {
+			case rec != nil:
+				status = http.StatusInternalServerError
+			case handlerErr != nil:
+				status = errorStatusCode(handlerErr)
+			case status == 0:
+				status = http.StatusOK
+			}
+			event := &HTTPAuditEvent{
+				Type:       AuditEventHTTPRequest,
+				Method:     r.Method,
+				Route:      GetRoutePattern(auditReq),
+				Path:       r.URL.Path,
+				PathParams: getPathParams(auditReq),
+				Body:       body,
+				StatusCode: status,
+				LatencyMs:  time.Since(st).Milliseconds(),
+			}
+			record := log.NewAuditRecord(auditReq.Context(), b.c.ServiceName, event)
+			if err := b.log.Audit(auditReq.Context(), record); err != nil {
+				b.log.Error(auditReq.Context(), "Audit write failed", err)
+			}
+			if rec != nil {
+				panic(rec)
+			}
+		}()
+		next.ServeHTTP(statusRW, auditReq)
+	})
+}
+
+func readAuditBody(r *http.Request, redactor *log.Redactor) (any, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	blob, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(blob))
+	if len(blob) == 0 {
+		return nil, nil
+	}
+	if json.Valid(blob) {
+		if redacted, err := redactor.RedactJSON(blob); err == nil {
+			if body, err := decodeAuditBody(redacted); err == nil {
+				return body, nil
+			}
+		}
+	}
+	return redactor.RedactString(string(blob)), nil
+}
+
+// decodeAuditBody decodes the body into plain maps and slices so document stores keep it queryable, integers stay int64
+func decodeAuditBody(blob []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(blob))
+	decoder.UseNumber()
+	var body any
+	if err := decoder.Decode(&body); err != nil {
+		return nil, err
+	}
+	return convertAuditNumbers(body), nil
+}
+
+func convertAuditNumbers(val any) any {
+	switch v := val.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = convertAuditNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = convertAuditNumbers(item)
+		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	}
+	return val
+}
+
+func getPathParams(r *http.Request) map[string]string {
+	params := make(map[string]string)
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return params
+	}
+	for i, key := range rctx.URLParams.Keys {
+		if key == "*" || i >= len(rctx.URLParams.Values) {
+			continue
+		}
+		params[key] = rctx.URLParams.Values[i]
+	}
+	return params
+}
+
+func errorStatusCode(err error) int {
+	var httpErr *errors.HTTPError
+	if e.As(err, &httpErr) {
+		return httpErr.ErrorStatusCode
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,111 @@
+package baseapp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sabariramc/goserverbase/baseapp"
+	"github.com/sabariramc/goserverbase/errors"
+	"github.com/sabariramc/goserverbase/log"
+	"gotest.tools/assert"
+)
+
+type recordingAuditWriter struct {
+	records []*log.AuditRecord
+}
+
+func (a *recordingAuditWriter) WriteMessage(ctx context.Context, msg interface{}) error {
+	a.records = append(a.records, msg.(*log.AuditRecord))
+	return nil
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	audit := &recordingAuditWriter{}
+	srv := baseapp.New(*ServerTestConfig.App, *ServerTestConfig.Logger, ServerTestLMux, nil, audit)
+	srv.GetRouter().Route("/tenant/{tenantId}", func(r chi.Router) {
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctx := context.WithValue(r.Context(), log.ContextKeyCustomerIdentifier, &log.CustomerIdentifier{CustomerId: "customer_1", AppUserId: "user_1"})
+				next.ServeHTTP(w, r.WithContext(ctx))
+			})
+		})
+		r.Use(srv.AuditMiddleware)
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		r.Put("/", func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			assert.NilError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, body["password"], "hunter2")
+			w.WriteHeader(http.StatusAccepted)
+		})
+		r.Delete("/", func(w http.ResponseWriter, r *http.Request) {
+			srv.SetHandlerError(r.Context(), errors.NewHTTPClientError(http.StatusConflict, "CONFLICT", "tenant in use", nil, nil))
+		})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/tenant/tenant_1", nil)
+	srv.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, len(audit.records), 0)
+
+	req = httptest.NewRequest(http.MethodPut, "/tenant/tenant_1", strings.NewReader(`{"name":"acme","password":"hunter2"}`))
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Code, http.StatusAccepted)
+	assert.Equal(t, len(audit.records), 1)
+	record := audit.records[0]
+	assert.Equal(t, record.Actor, "user_1")
+	assert.Equal(t, record.Customer.CustomerId, "customer_1")
+	event := record.Event.(*baseapp.HTTPAuditEvent)
+	assert.Equal(t, event.Method, http.MethodPut)
+	assert.Equal(t, event.Route, "/tenant/{tenantId}")
+	assert.DeepEqual(t, event.PathParams, map[string]string{"tenantId": "tenant_1"})
+	assert.Equal(t, event.StatusCode, http.StatusAccepted)
+	assert.DeepEqual(t, event.Body, map[string]any{"name": "acme", "password": log.RedactedValue})
+
+	req = httptest.NewRequest(http.MethodDelete, "/tenant/tenant_1", nil)
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Code, http.StatusConflict)
+	assert.Equal(t, len(audit.records), 2)
+	assert.Equal(t, audit.records[1].Event.(*baseapp.HTTPAuditEvent).StatusCode, http.StatusConflict)
+}
+
+func TestAuditMiddlewareAlwaysRedacts(t *testing.T) {
+	audit := &recordingAuditWriter{}
+	lConfig := *ServerTestConfig.Logger
+	lConfig.DisableRedaction = true
+	srv := baseapp.New(*ServerTestConfig.App, lConfig, ServerTestLMux, nil, audit)
+	srv.GetRouter().With(srv.AuditMiddleware).Post("/tenant", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	req := httptest.NewRequest(http.MethodPost, "/tenant", strings.NewReader(`{"password":"hunter2","seats":12,"plan":{"price":9.5}}`))
+	srv.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, len(audit.records), 1)
+	event := audit.records[0].Event.(*baseapp.HTTPAuditEvent)
+	assert.DeepEqual(t, event.Body, map[string]any{"password": log.RedactedValue, "seats": int64(12), "plan": map[string]any{"price": 9.5}})
+}
+
+func TestAuditMiddlewareBodyTooLarge(t *testing.T) {
+	audit := &recordingAuditWriter{}
+	appConfig := *ServerTestConfig.App
+	appConfig.MaxRequestBodySize = 8
+	srv := baseapp.New(appConfig, *ServerTestConfig.Logger, ServerTestLMux, nil, audit)
+	called := false
+	srv.GetRouter().With(srv.AuditMiddleware).Post("/tenant", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusCreated)
+	})
+	req := httptest.NewRequest(http.MethodPost, "/tenant", strings.NewReader(`{"name":"acme"}`))
+	req.ContentLength = -1
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Code, http.StatusRequestEntityTooLarge)
+	assert.Assert(t, !called)
+	assert.Equal(t, len(audit.records), 0)
+}
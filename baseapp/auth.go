@@ -0,0 +1,98 @@
+package baseapp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sabariramc/goserverbase/auth"
+	"github.com/sabariramc/goserverbase/errors"
+	"github.com/sabariramc/goserverbase/log"
+)
+
+const (
+	ErrorCodeUnauthorized     = "UNAUTHORIZED"
+	ErrorCodeForbidden        = "FORBIDDEN"
+	HttpHeaderAuthorization   = "Authorization"
+	HttpHeaderWWWAuthenticate = "WWW-Authenticate"
+)
+
+func (b *BaseApp) SetAuthVerifier(verifier *auth.Verifier) {
+	b.verifier = verifier
+}
+
+// AuthenticationMiddleware needs the verifier set with SetAuthVerifier before routes using it are registered
+func (b *BaseApp) AuthenticationMiddleware(next http.Handler) http.Handler {
+	if b.verifier == nil {
+		b.log.Emergency(context.Background(), "Auth verifier not set, call SetAuthVerifier before registering authenticated routes", nil, fmt.Errorf("BaseApp.AuthenticationMiddleware: auth verifier not set"))
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		token, ok := getBearerToken(r)
+		if !ok {
+			b.sendUnauthorized(ctx, w, "Missing bearer token", `Bearer`)
+			return
+		}
+		claims, err := b.verifier.Verify(ctx, token)
+		if err != nil {
+			b.log.Notice(ctx, "Token verification failed", err)
+			b.sendUnauthorized(ctx, w, "Invalid bearer token", `Bearer error="invalid_token"`)
+			return
+		}
+		ctx = auth.SetClaims(ctx, claims)
+		ctx = context.WithValue(ctx, log.ContextKeyCustomerIdentifier, b.verifier.GetCustomerIdentifier(claims))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func getBearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get(HttpHeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func (b *BaseApp) sendUnauthorized(ctx context.Context, w http.ResponseWriter, message, challenge string) {
+	w.Header().Set(HttpHeaderWWWAuthenticate, challenge)
+	b.SendErrorResponse(ctx, w, "", errors.NewHTTPClientError(http.StatusUnauthorized, ErrorCodeUnauthorized, message, nil, nil))
+}
+
+func (b *BaseApp) RequireScope(scopes ...string) func(http.Handler) http.Handler {
+	return b.requireClaimValues("scope", scopes, func(claims auth.Claims) []string { return b.verifier.GetScopes(claims) })
+}
+
+func (b *BaseApp) RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return b.requireClaimValues("role", roles, func(claims auth.Claims) []string { return b.verifier.GetRoles(claims) })
+}
+
+func (b *BaseApp) requireClaimValues(kind string, required []string, getter func(auth.Claims) []string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			claims := auth.GetClaims(ctx)
+			if len(claims) == 0 {
+				b.sendUnauthorized(ctx, w, "Missing bearer token", `Bearer`)
+				return
+			}
+			granted := make(map[string]bool)
+			for _, val := range getter(claims) {
+				granted[val] = true
+			}
+			missing := make([]string, 0)
+			for _, val := range required {
+				if !granted[val] {
+					missing = append(missing, val)
+				}
+			}
+			if len(missing) > 0 {
+				b.log.Notice(ctx, "Insufficient "+kind, missing)
+				b.SendErrorResponse(ctx, w, "", errors.NewHTTPClientError(http.StatusForbidden, ErrorCodeForbidden, "Insufficient "+kind, nil, map[string]any{"required": missing}))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package baseapp_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sabariramc/goserverbase/auth"
+	"github.com/sabariramc/goserverbase/baseapp"
+	"github.com/sabariramc/goserverbase/log"
+	"gotest.tools/assert"
+)
+
+func newHS256Token(secret string, claims map[string]any) string {
+	encode := func(val any) string {
+		blob, _ := json.Marshal(val)
+		return base64.RawURLEncoding.EncodeToString(blob)
+	}
+	input := encode(map[string]any{"alg": "HS256", "typ": "JWT"}) + "." + encode(claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(input))
+	return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestAuthenticationMiddleware(t *testing.T) {
+	srv := baseapp.New(*ServerTestConfig.App, *ServerTestConfig.Logger, ServerTestLMux, nil, nil)
+	verifier, err := auth.NewVerifier(context.TODO(), ServerTestLogger, auth.Config{HS256Secret: "secret", AppUserIdClaim: "uid"})
+	assert.NilError(t, err)
+	srv.SetAuthVerifier(verifier)
+	srv.GetRouter().Group(func(r chi.Router) {
+		r.Use(srv.AuthenticationMiddleware)
+		r.Get("/secure", func(w http.ResponseWriter, r *http.Request) {
+			baseapp.WriteJson(w, log.GetCustomerIdentifier(r.Context()))
+		})
+		r.With(srv.RequireScope("tenant:write")).Post("/secure", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+		r.With(srv.RequireRole("admin")).Delete("/secure", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+	})
+	token := newHS256Token("secret", map[string]any{"sub": "customer_1", "uid": "user_1", "scope": "tenant:write", "exp": time.Now().Add(time.Minute).Unix()})
+
+	req := httptest.NewRequest(http.MethodGet, "/secure", nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Result().StatusCode, http.StatusUnauthorized)
+	assert.Equal(t, w.Result().Header.Get("WWW-Authenticate"), "Bearer")
+
+	req = httptest.NewRequest(http.MethodGet, "/secure", nil)
+	req.Header.Set("Authorization", "Bearer "+newHS256Token("wrong", map[string]any{"sub": "customer_1"}))
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Result().StatusCode, http.StatusUnauthorized)
+	res := make(map[string]any)
+	json.Unmarshal(w.Body.Bytes(), &res)
+	assert.Equal(t, res["errorCode"], "UNAUTHORIZED")
+
+	req = httptest.NewRequest(http.MethodGet, "/secure", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Result().StatusCode, http.StatusOK)
+	res = make(map[string]any)
+	json.Unmarshal(w.Body.Bytes(), &res)
+	assert.DeepEqual(t, res, map[string]any{"x-customer-id": "customer_1", "x-appUser-id": "user_1", "x-entity-id": ""})
+
+	req = httptest.NewRequest(http.MethodPost, "/secure", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Result().StatusCode, http.StatusNoContent)
+
+	req = httptest.NewRequest(http.MethodDelete, "/secure", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Result().StatusCode, http.StatusForbidden)
+	res = make(map[string]any)
+	json.Unmarshal(w.Body.Bytes(), &res)
+	assert.DeepEqual(t, res["errorDescription"], map[string]any{"required": []any{"admin"}})
+}
+
+func TestAuthenticationMiddlewareRequiresVerifier(t *testing.T) {
+	srv := baseapp.New(*ServerTestConfig.App, *ServerTestConfig.Logger, ServerTestLMux, nil, nil)
+	defer func() {
+		assert.Assert(t, recover() != nil)
+	}()
+	srv.GetRouter().With(srv.AuthenticationMiddleware).Get("/secure", func(w http.ResponseWriter, r *http.Request) {})
+	t.Fatal("route registered without an auth verifier")
+}
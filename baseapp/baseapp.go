@@ -3,21 +3,36 @@ package baseapp
 import (
 	"context"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/sabariramc/goserverbase/auth"
 	"github.com/sabariramc/goserverbase/config"
 	"github.com/sabariramc/goserverbase/errors"
+	"github.com/sabariramc/goserverbase/health"
 	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/log/logwriter"
 )
 
 type BaseApp struct {
-	handler       *chi.Mux
-	c             *config.ServerConfig
-	lConfig       *log.Config
-	log           *log.Logger
-	errorNotifier errors.ErrorNotifier
-	docMeta       APIDocumentation
+	handler          *chi.Mux
+	c                *config.ServerConfig
+	lConfig          *log.Config
+	log              *log.Logger
+	errorNotifier    errors.ErrorNotifier
+	docMeta          APIDocumentation
+	server           *http.Server
+	shutdownHooks    []ShutdownHook
+	shutdownLock     sync.Mutex
+	shutdownOnce     sync.Once
+	shutdownErr      error
+	verifier         *auth.Verifier
+	adminMiddlewares []func(http.Handler) http.Handler
+	ringWriter       *logwriter.RingWriter
+	health           *health.Registry
+	readiness        atomic.Value
 }
 
 func New(appConfig config.ServerConfig, loggerConfig log.Config, lMux log.LogMux, errorNotifier errors.ErrorNotifier, auditLogger log.AuditLogWriter) *BaseApp {
@@ -26,6 +41,7 @@ func New(appConfig config.ServerConfig, loggerConfig log.Config, lMux log.LogMux
 		lConfig:       &loggerConfig,
 		handler:       chi.NewRouter(),
 		errorNotifier: errorNotifier,
+		health:        health.NewRegistry(),
 		docMeta: APIDocumentation{
 			Server: make([]DocumentServer, 0),
 			Routes: make(APIRoute, 0),
@@ -33,6 +49,13 @@ func New(appConfig config.ServerConfig, loggerConfig log.Config, lMux log.LogMux
 	}
 	ctx := b.GetCorrelationContext(context.Background(), log.GetDefaultCorrelationParams(appConfig.ServiceName))
 	b.log = log.NewLogger(ctx, &loggerConfig, loggerConfig.ServiceName, lMux, auditLogger)
+	if err := appConfig.CORS.Validate(); err != nil {
+		b.log.Emergency(ctx, "Invalid CORS config", err, err)
+	}
+	if hook, ok := lMux.(ShutdownHook); ok {
+		b.RegisterOnShutdown(hook)
+	}
+	b.RegisterOnShutdown(b.log)
 	zone, _ := time.Now().Zone()
 	b.log.Notice(ctx, "Timezone", zone)
 	b.SetupRouter(ctx)
@@ -0,0 +1,155 @@
+package baseapp
+
+import (
+	"bytes"
+	"encoding/json"
+	e "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (b *BaseApp) Bind(r *http.Request, dest interface{}) bool {
+	err := BindRequest(r, dest)
+	if err != nil {
+		b.log.Notice(r.Context(), "Request binding failed", err)
+		b.SetHandlerError(r.Context(), err)
+		return false
+	}
+	return true
+}
+
+func BindRequest(r *http.Request, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		panic(fmt.Errorf("baseapp.BindRequest: destination should be a non nil pointer to a struct, got %T", dest))
+	}
+	fieldErrors := make([]FieldError, 0)
+	if r.Body != nil && r.Body != http.NoBody {
+		blob, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(blob))
+		if err != nil {
+			return fmt.Errorf("baseapp.BindRequest: %w", err)
+		}
+		if len(bytes.TrimSpace(blob)) > 0 {
+			if fieldError := decodeJSONBody(blob, dest); fieldError != nil {
+				return NewValidationError([]FieldError{*fieldError})
+			}
+		}
+	}
+	bindParams(r, v.Elem(), &fieldErrors)
+	if len(fieldErrors) > 0 {
+		return NewValidationError(fieldErrors)
+	}
+	return ValidateStruct(dest)
+}
+
+func decodeJSONBody(blob []byte, dest interface{}) *FieldError {
+	err := json.Unmarshal(blob, dest)
+	if err == nil {
+		return nil
+	}
+	var typeErr *json.UnmarshalTypeError
+	if e.As(err, &typeErr) && typeErr.Field != "" {
+		return &FieldError{Field: typeErr.Field, Error: "must be of type " + typeErr.Type.String()}
+	}
+	return &FieldError{Field: "body", Error: "invalid JSON: " + err.Error()}
+}
+
+func bindParams(r *http.Request, v reflect.Value, fieldErrors *[]FieldError) {
+	query := r.URL.Query()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			bindParams(r, fv, fieldErrors)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		var values []string
+		name, in := fieldDocName(field)
+		switch in {
+		case "path":
+			val := chi.URLParam(r, name)
+			if val == "" {
+				continue
+			}
+			values = []string{val}
+		case "query":
+			var ok bool
+			values, ok = query[name]
+			if !ok {
+				continue
+			}
+		default:
+			continue
+		}
+		if err := setFromStrings(fv, values); err != nil {
+			*fieldErrors = append(*fieldErrors, FieldError{Field: name, Error: err.Error()})
+		}
+	}
+}
+
+func setFromStrings(v reflect.Value, values []string) error {
+	if v.Kind() == reflect.Pointer {
+		ptr := reflect.New(v.Type().Elem())
+		if err := setFromStrings(ptr.Elem(), values); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, val := range values {
+			if err := setFromString(slice.Index(i), val); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+	return setFromString(v, values[len(values)-1])
+}
+
+func setFromString(v reflect.Value, val string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(val)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return fmt.Errorf("must be a boolean")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(val, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be an integer")
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(val, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be a non negative integer")
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(val, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be a number")
+		}
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported parameter type %v", v.Type())
+	}
+	return nil
+}
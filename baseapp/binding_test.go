@@ -0,0 +1,160 @@
+package baseapp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sabariramc/goserverbase/baseapp"
+	"github.com/sabariramc/goserverbase/errors"
+	"gotest.tools/assert"
+)
+
+type bindItem struct {
+	SKU      string `json:"sku" validate:"required,regex=^[A-Z]{3}-[0-9]+$"`
+	Quantity int    `json:"quantity" validate:"min=1,max=10"`
+}
+
+type bindRequest struct {
+	baseapp.Filter
+	TenantId string     `json:"-" path:"tenantId" validate:"required"`
+	DryRun   *bool      `json:"-" schema:"dryRun"`
+	Name     string     `json:"name" validate:"required,min=3,max=20"`
+	Status   string     `json:"status" validate:"enum=ACTIVE|INACTIVE"`
+	Items    []bindItem `json:"items" validate:"required,min=1"`
+}
+
+func newBindServer(res *bindRequest) *baseapp.BaseApp {
+	srv := baseapp.New(*ServerTestConfig.App, *ServerTestConfig.Logger, ServerTestLMux, nil, nil)
+	srv.RegisterRoutes(context.TODO(), http.MethodPost, "/tenant/{tenantId}/order", &baseapp.APIHandler{
+		Payload: &bindRequest{},
+		Func: func(w http.ResponseWriter, r *http.Request) {
+			if !srv.Bind(r, res) {
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	return srv
+}
+
+func TestBindRequest(t *testing.T) {
+	res := &bindRequest{}
+	srv := newBindServer(res)
+	body := `{"name":"order","status":"ACTIVE","items":[{"sku":"ABC-12","quantity":2}]}`
+	req := httptest.NewRequest(http.MethodPost, "/tenant/tenant_1/order?dryRun=true&pageNo=2&sortBy=name", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Result().StatusCode, http.StatusNoContent)
+	assert.Equal(t, res.TenantId, "tenant_1")
+	assert.Equal(t, *res.DryRun, true)
+	assert.Equal(t, res.PageNo, int64(2))
+	assert.Equal(t, res.SortBy, "name")
+	assert.Equal(t, res.Items[0].Quantity, 2)
+}
+
+func TestBindRequestValidationError(t *testing.T) {
+	srv := newBindServer(&bindRequest{})
+	body := `{"name":"or","status":"UNKNOWN","items":[{"sku":"abc","quantity":20}]}`
+	req := httptest.NewRequest(http.MethodPost, "/tenant/tenant_1/order?pageNo=two", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Result().StatusCode, http.StatusBadRequest)
+	res := make(map[string]any)
+	json.Unmarshal(w.Body.Bytes(), &res)
+	assert.DeepEqual(t, res, map[string]any{
+		"errorCode":    "VALIDATION_ERROR",
+		"errorMessage": "Invalid request",
+		"errorDescription": []any{
+			map[string]any{"field": "pageNo", "error": "must be an integer"},
+		},
+	})
+	req = httptest.NewRequest(http.MethodPost, "/tenant/tenant_1/order", strings.NewReader(body))
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Result().StatusCode, http.StatusBadRequest)
+	res = make(map[string]any)
+	json.Unmarshal(w.Body.Bytes(), &res)
+	assert.DeepEqual(t, res["errorDescription"], []any{
+		map[string]any{"field": "name", "error": "must be at least 3 characters"},
+		map[string]any{"field": "status", "error": "must be one of ACTIVE, INACTIVE"},
+		map[string]any{"field": "items[0].sku", "error": "must match pattern ^[A-Z]{3}-[0-9]+$"},
+		map[string]any{"field": "items[0].quantity", "error": "must be at most 10"},
+	})
+	req = httptest.NewRequest(http.MethodPost, "/tenant/tenant_1/order", strings.NewReader(`{"name": 12}`))
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	res = make(map[string]any)
+	json.Unmarshal(w.Body.Bytes(), &res)
+	assert.DeepEqual(t, res["errorDescription"], []any{map[string]any{"field": "name", "error": "must be of type string"}})
+}
+
+func TestBindRequestOpenAPI(t *testing.T) {
+	srv := newBindServer(&bindRequest{})
+	spec := srv.GetOpenAPISpec()
+	op := spec.Paths["/tenant/{tenantId}/order"]["post"]
+	names := make([]string, len(op.Parameters))
+	for i, p := range op.Parameters {
+		names[i] = p.In + ":" + p.Name
+	}
+	assert.DeepEqual(t, names, []string{"path:tenantId", "query:asc", "query:dryRun", "query:limit", "query:pageNo", "query:sortBy"})
+	schema := spec.Components.Schemas["bindRequest"]
+	assert.DeepEqual(t, schema.Required, []string{"name", "items"})
+	_, ok := schema.Properties["pageNo"]
+	assert.Assert(t, !ok)
+	assert.Equal(t, *schema.Properties["name"].MinLength, int64(3))
+	assert.DeepEqual(t, schema.Properties["status"].Enum, []string{"ACTIVE", "INACTIVE"})
+	item := spec.Components.Schemas["bindItem"]
+	assert.Equal(t, item.Properties["sku"].Pattern, "^[A-Z]{3}-[0-9]+$")
+	assert.Equal(t, *item.Properties["quantity"].Maximum, float64(10))
+}
+
+func TestValidateStructZeroValues(t *testing.T) {
+	type payload struct {
+		Quantity int      `json:"quantity" validate:"min=1"`
+		Status   string   `json:"status" validate:"enum=ACTIVE|INACTIVE"`
+		Note     string   `json:"note,omitempty" validate:"min=3"`
+		Discount *int     `json:"discount" validate:"min=1"`
+		Tags     []string `json:"tags,omitempty" validate:"min=1"`
+	}
+	err := baseapp.ValidateStruct(&payload{})
+	httpErr, ok := err.(*errors.HTTPError)
+	assert.Assert(t, ok, err)
+	assert.DeepEqual(t, httpErr.ErrorDescription, []baseapp.FieldError{
+		{Field: "quantity", Error: "must be at least 1"},
+		{Field: "status", Error: "must be one of ACTIVE, INACTIVE"},
+	})
+	zero := 0
+	err = baseapp.ValidateStruct(&payload{Quantity: 1, Status: "ACTIVE", Discount: &zero})
+	httpErr, ok = err.(*errors.HTTPError)
+	assert.Assert(t, ok, err)
+	assert.DeepEqual(t, httpErr.ErrorDescription, []baseapp.FieldError{{Field: "discount", Error: "must be at least 1"}})
+}
+
+func TestCheckValidationRules(t *testing.T) {
+	type nested struct {
+		Code string `json:"code" validate:"regex=[a-"`
+	}
+	type payload struct {
+		Items []nested `json:"items"`
+	}
+	type unknownRule struct {
+		Name string `json:"name" validate:"requried"`
+	}
+	type badBound struct {
+		Count int `json:"count" validate:"min=one"`
+	}
+	assert.NilError(t, baseapp.CheckValidationRules(&bindRequest{}))
+	assert.ErrorContains(t, baseapp.CheckValidationRules(&payload{}), "invalid regex rule")
+	assert.ErrorContains(t, baseapp.CheckValidationRules(unknownRule{}), "unknown validation rule `requried`")
+	assert.ErrorContains(t, baseapp.CheckValidationRules(&badBound{}), "invalid min rule `one`")
+	assert.ErrorContains(t, baseapp.ValidateStruct(&badBound{Count: 1}), "invalid min rule `one`")
+	srv := baseapp.New(*ServerTestConfig.App, *ServerTestConfig.Logger, ServerTestLMux, nil, nil)
+	defer func() {
+		assert.Assert(t, recover() != nil)
+	}()
+	srv.RegisterRoutes(context.TODO(), http.MethodPost, "/bad", &baseapp.APIHandler{Payload: &unknownRule{}, Func: func(w http.ResponseWriter, r *http.Request) {}})
+}
@@ -0,0 +1,43 @@
+package baseapp
+
+import (
+	"net/http"
+
+	"github.com/sabariramc/goserverbase/health"
+)
+
+func (b *BaseApp) RegisterHealthCheck(name string, checker health.Checker, config health.CheckConfig) {
+	b.health.Register(name, checker, config)
+}
+
+func (b *BaseApp) GetHealthRegistry() *health.Registry {
+	return b.health
+}
+
+func (b *BaseApp) LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, b.health.Liveness(r.Context()))
+}
+
+func (b *BaseApp) ReadinessHandler(w http.ResponseWriter, r *http.Request) {
+	report := b.health.Readiness(r.Context())
+	previous, _ := b.readiness.Swap(report.Status).(string)
+	if previous == "" {
+		previous = health.StatusUp
+	}
+	if report.Status != previous {
+		if report.Status == health.StatusUp {
+			b.log.Notice(r.Context(), "Readiness check recovered from "+previous, report)
+		} else {
+			b.log.Warning(r.Context(), "Readiness check "+report.Status, report)
+		}
+	}
+	writeHealthReport(w, report)
+}
+
+func writeHealthReport(w http.ResponseWriter, report health.Report) {
+	status := http.StatusOK
+	if report.Status == health.StatusDown {
+		status = http.StatusServiceUnavailable
+	}
+	WriteJsonWithStatusCode(w, status, report)
+}
@@ -0,0 +1,69 @@
+package baseapp_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sabariramc/goserverbase/baseapp"
+	"github.com/sabariramc/goserverbase/baseapp/test/server"
+	"github.com/sabariramc/goserverbase/health"
+	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/log/logtest"
+	"gotest.tools/assert"
+)
+
+func TestHealthEndpoints(t *testing.T) {
+	srv := server.NewServer()
+	srv.RegisterHealthCheck("mongo", health.CheckerFunc(func(ctx context.Context) error {
+		return fmt.Errorf("server selection timeout")
+	}), health.CheckConfig{Critical: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/meta/health", nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Code, http.StatusNoContent)
+
+	req = httptest.NewRequest(http.MethodGet, "/meta/live", nil)
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Code, http.StatusOK)
+
+	req = httptest.NewRequest(http.MethodGet, "/meta/ready", nil)
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Code, http.StatusServiceUnavailable)
+	var report health.Report
+	assert.NilError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, report.Status, health.StatusDown)
+	assert.Equal(t, report.Checks["mongo"].Error, "server selection timeout")
+}
+
+func TestReadinessLogsTransitionsOnly(t *testing.T) {
+	capture := logtest.NewCaptureWriter()
+	srv := baseapp.New(*ServerTestConfig.App, *ServerTestConfig.Logger, log.NewDefaultLogMux(capture), nil, nil)
+	var down atomic.Bool
+	down.Store(true)
+	srv.RegisterHealthCheck("mongo", health.CheckerFunc(func(ctx context.Context) error {
+		if down.Load() {
+			return fmt.Errorf("server selection timeout")
+		}
+		return nil
+	}), health.CheckConfig{Critical: true})
+	probe := func() {
+		srv.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/meta/ready", nil))
+	}
+	probe()
+	probe()
+	probe()
+	assert.Equal(t, len(capture.Find(log.WARNING, "^Readiness check")), 1)
+	down.Store(false)
+	probe()
+	probe()
+	assert.Equal(t, len(capture.Find(log.NOTICE, "^Readiness check recovered")), 1)
+	assert.Equal(t, len(capture.Find(log.WARNING, "^Readiness check")), 1)
+}
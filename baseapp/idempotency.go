@@ -0,0 +1,180 @@
+package baseapp
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	e "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sabariramc/goserverbase/errors"
+	"github.com/sabariramc/goserverbase/idempotency"
+	"github.com/sabariramc/goserverbase/log"
+)
+
+const (
+	HttpHeaderIdempotencyKey      = "Idempotency-Key"
+	HttpHeaderIdempotencyReplayed = "Idempotent-Replayed"
+	DefaultIdempotencyTTL         = time.Hour * 24
+	DefaultIdempotencyLease       = time.Minute
+)
+
+type capturingResponseWriter struct {
+	status int
+	body   bytes.Buffer
+	http.ResponseWriter
+}
+
+func (w *capturingResponseWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *capturingResponseWriter) Write(body []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.body.Write(body)
+	return w.ResponseWriter.Write(body)
+}
+
+// IdempotencyMiddleware holds the key for lease while the request runs and keeps the response for ttl.
+// The lease is renewed every lease/2 until the handler returns, so it only lapses and lets a retry take over when the process dies
+
+func (b *BaseApp) IdempotencyMiddleware(store idempotency.Store, ttl, lease time.Duration) func(http.Handler) http.Handler {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	if lease <= 0 {
+		lease = DefaultIdempotencyLease
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			key := r.Header.Get(HttpHeaderIdempotencyKey)
+			if key == "" || r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+			var body []byte
+			if r.Body != nil {
+				var err error
+				body, err = io.ReadAll(r.Body)
+				r.Body.Close()
+				if err != nil {
+					b.log.Notice(ctx, "Idempotency request body read failed", err)
+					b.SendErrorResponse(ctx, w, "", fmt.Errorf("BaseApp.IdempotencyMiddleware: %w", err))
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(body))
+			}
+			hash := sha256.Sum256(body)
+			record := &idempotency.Record{
+				Key:      fmt.Sprintf("%v:%v:%v:%v", log.GetCustomerIdentifier(ctx).CustomerId, r.Method, r.URL.Path, key),
+				Owner:    uuid.NewString(),
+				BodyHash: hex.EncodeToString(hash[:]),
+				ExpireAt: time.Now().Add(lease),
+			}
+			existing, err := store.Begin(ctx, record)
+			if err != nil {
+				b.log.Error(ctx, "Idempotency store error", err)
+				b.SendErrorResponse(ctx, w, "", fmt.Errorf("BaseApp.IdempotencyMiddleware: %w", err))
+				return
+			}
+			if existing != nil {
+				b.replayIdempotentResponse(ctx, w, record, existing)
+				return
+			}
+			var handlerErr error
+			captureRW := &capturingResponseWriter{ResponseWriter: w}
+			completed := false
+			stopRenew := sync.OnceFunc(b.renewIdempotencyLease(context.WithoutCancel(ctx), store, record.Key, record.Owner, lease))
+			defer func() {
+				stopRenew()
+				if !completed {
+					if err := store.Release(context.WithoutCancel(ctx), record); err != nil {
+						b.log.Error(ctx, "Idempotency key release failed", err)
+					}
+				}
+			}()
+			next.ServeHTTP(captureRW, r.WithContext(context.WithValue(ctx, ContextKeyError, func(err error) { handlerErr = err })))
+			stopRenew()
+			if handlerErr != nil {
+				b.SendErrorResponse(ctx, captureRW, "", handlerErr)
+			}
+			status := captureRW.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if status >= http.StatusInternalServerError {
+				return
+			}
+			record.StatusCode = status
+			record.Header = captureRW.Header().Clone()
+			record.Body = captureRW.body.Bytes()
+			record.ExpireAt = time.Now().Add(ttl)
+			if err := store.Complete(context.WithoutCancel(ctx), record); err != nil {
+				b.log.Error(ctx, "Idempotency record save failed", err)
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+// renewIdempotencyLease extends the lease of the owned record every lease/2 until the returned func is called
+func (b *BaseApp) renewIdempotencyLease(ctx context.Context, store idempotency.Store, key, owner string, lease time.Duration) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(lease / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				err := store.Renew(ctx, &idempotency.Record{Key: key, Owner: owner, ExpireAt: time.Now().Add(lease)})
+				if e.Is(err, idempotency.ErrNotOwner) {
+					b.log.Warning(ctx, "Idempotency lease lost", err)
+					return
+				}
+				if err != nil {
+					b.log.Error(ctx, "Idempotency lease renewal failed", err)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+func (b *BaseApp) replayIdempotentResponse(ctx context.Context, w http.ResponseWriter, record, existing *idempotency.Record) {
+	if existing.BodyHash != record.BodyHash {
+		b.SendErrorResponse(ctx, w, "", errors.NewHTTPClientError(http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_MISMATCH", "Idempotency key reused with a different request body", nil, nil))
+		return
+	}
+	if existing.InProgress {
+		b.SendErrorResponse(ctx, w, "", errors.NewHTTPClientError(http.StatusConflict, "IDEMPOTENCY_REQUEST_IN_PROGRESS", "A request with this idempotency key is in progress", nil, nil))
+		return
+	}
+	b.log.Info(ctx, "Replaying idempotent response", map[string]any{"statusCode": existing.StatusCode})
+	h := w.Header()
+	for key, val := range existing.Header {
+		h[key] = val
+	}
+	h.Set(HttpHeaderIdempotencyReplayed, "true")
+	w.WriteHeader(existing.StatusCode)
+	w.Write(existing.Body)
+}
@@ -0,0 +1,160 @@
+package baseapp_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	e "errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sabariramc/goserverbase/baseapp"
+	"github.com/sabariramc/goserverbase/errors"
+	"github.com/sabariramc/goserverbase/idempotency"
+	"gotest.tools/assert"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	srv := baseapp.New(*ServerTestConfig.App, *ServerTestConfig.Logger, ServerTestLMux, nil, nil)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	started := make(chan struct{})
+	store := idempotency.NewMemoryStore()
+	srv.GetRouter().With(srv.IdempotencyMiddleware(store, time.Minute, 50*time.Millisecond)).Post("/payment", func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		switch r.URL.Query().Get("mode") {
+		case "slow":
+			close(started)
+			<-release
+		case "fail":
+			if n == 1 {
+				srv.SetHandlerError(r.Context(), errors.NewHTTPServerError(http.StatusBadGateway, "UPSTREAM", "upstream failed", nil, nil))
+				return
+			}
+		}
+		w.Header().Set("x-payment-id", "pay_1")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"pay_1"}`))
+	})
+	send := func(key, query, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/payment"+query, strings.NewReader(body))
+		req.Header.Set(baseapp.HttpHeaderIdempotencyKey, key)
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("key-1", "", `{"amount":100}`)
+	assert.Equal(t, w.Code, http.StatusCreated)
+	assert.Equal(t, w.Header().Get(baseapp.HttpHeaderIdempotencyReplayed), "")
+	w = send("key-1", "", `{"amount":100}`)
+	assert.Equal(t, w.Code, http.StatusCreated)
+	assert.Equal(t, w.Body.String(), `{"id":"pay_1"}`)
+	assert.Equal(t, w.Header().Get("x-payment-id"), "pay_1")
+	assert.Equal(t, w.Header().Get(baseapp.HttpHeaderIdempotencyReplayed), "true")
+	assert.Equal(t, calls.Load(), int32(1))
+
+	w = send("key-1", "", `{"amount":200}`)
+	assert.Equal(t, w.Code, http.StatusUnprocessableEntity)
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- send("key-2", "?mode=slow", `{"amount":100}`) }()
+	<-started
+	w = send("key-2", "?mode=slow", `{"amount":100}`)
+	assert.Equal(t, w.Code, http.StatusConflict)
+	close(release)
+	assert.Equal(t, (<-done).Code, http.StatusCreated)
+
+	calls.Store(0)
+	w = send("key-3", "?mode=fail", `{"amount":100}`)
+	assert.Equal(t, w.Code, http.StatusBadGateway)
+	w = send("key-3", "?mode=fail", `{"amount":100}`)
+	assert.Equal(t, w.Code, http.StatusCreated)
+	assert.Equal(t, w.Header().Get(baseapp.HttpHeaderIdempotencyReplayed), "")
+	assert.Equal(t, calls.Load(), int32(2))
+
+	hash := sha256.Sum256([]byte(`{"amount":100}`))
+	_, err := store.Begin(context.Background(), &idempotency.Record{Key: ":POST:/payment:key-4", BodyHash: hex.EncodeToString(hash[:]), ExpireAt: time.Now().Add(50 * time.Millisecond)})
+	assert.NilError(t, err)
+	w = send("key-4", "", `{"amount":100}`)
+	assert.Equal(t, w.Code, http.StatusConflict)
+	time.Sleep(60 * time.Millisecond)
+	w = send("key-4", "", `{"amount":100}`)
+	assert.Equal(t, w.Code, http.StatusCreated)
+	time.Sleep(60 * time.Millisecond)
+	w = send("key-4", "", `{"amount":100}`)
+	assert.Equal(t, w.Header().Get(baseapp.HttpHeaderIdempotencyReplayed), "true")
+}
+
+func TestIdempotencyMiddlewareBodyTooLarge(t *testing.T) {
+	appConfig := *ServerTestConfig.App
+	appConfig.MaxRequestBodySize = 8
+	srv := baseapp.New(appConfig, *ServerTestConfig.Logger, ServerTestLMux, nil, nil)
+	var calls atomic.Int32
+	srv.GetRouter().With(srv.IdempotencyMiddleware(idempotency.NewMemoryStore(), 0, 0)).Post("/payment", func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusCreated)
+	})
+	req := httptest.NewRequest(http.MethodPost, "/payment", strings.NewReader(`{"amount":100}`))
+	req.ContentLength = -1
+	req.Header.Set(baseapp.HttpHeaderIdempotencyKey, "key-1")
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Code, http.StatusRequestEntityTooLarge)
+	assert.Equal(t, calls.Load(), int32(0))
+}
+
+func TestIdempotencyMiddlewareHandlerOutlivesLease(t *testing.T) {
+	srv := baseapp.New(*ServerTestConfig.App, *ServerTestConfig.Logger, ServerTestLMux, nil, nil)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	srv.GetRouter().With(srv.IdempotencyMiddleware(idempotency.NewMemoryStore(), time.Minute, 20*time.Millisecond)).Post("/payment", func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		started <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusCreated)
+	})
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/payment", strings.NewReader(`{"amount":100}`))
+		req.Header.Set(baseapp.HttpHeaderIdempotencyKey, "key-1")
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, req)
+		return w
+	}
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- send() }()
+	<-started
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, send().Code, http.StatusConflict)
+	close(release)
+	assert.Equal(t, (<-done).Code, http.StatusCreated)
+	w := send()
+	assert.Equal(t, w.Code, http.StatusCreated)
+	assert.Equal(t, w.Header().Get(baseapp.HttpHeaderIdempotencyReplayed), "true")
+	assert.Equal(t, calls.Load(), int32(1))
+}
+
+func TestIdempotencyStoreOwnership(t *testing.T) {
+	ctx := context.Background()
+	store := idempotency.NewMemoryStore()
+	stale := &idempotency.Record{Key: "key-1", Owner: "a", ExpireAt: time.Now().Add(10 * time.Millisecond)}
+	existing, err := store.Begin(ctx, stale)
+	assert.NilError(t, err)
+	assert.Assert(t, existing == nil)
+	time.Sleep(20 * time.Millisecond)
+	existing, err = store.Begin(ctx, &idempotency.Record{Key: "key-1", Owner: "b", ExpireAt: time.Now().Add(time.Minute)})
+	assert.NilError(t, err)
+	assert.Assert(t, existing == nil)
+	assert.Assert(t, e.Is(store.Renew(ctx, stale), idempotency.ErrNotOwner))
+	assert.Assert(t, e.Is(store.Complete(ctx, stale), idempotency.ErrNotOwner))
+	assert.Assert(t, e.Is(store.Release(ctx, stale), idempotency.ErrNotOwner))
+	existing, err = store.Begin(ctx, &idempotency.Record{Key: "key-1", Owner: "c", ExpireAt: time.Now().Add(time.Minute)})
+	assert.NilError(t, err)
+	assert.Equal(t, existing.Owner, "b")
+	assert.Assert(t, existing.InProgress)
+}
@@ -0,0 +1,307 @@
+package baseapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int64             `json:"minLength,omitempty"`
+	MaxLength            *int64             `json:"maxLength,omitempty"`
+	MinItems             *int64             `json:"minItems,omitempty"`
+	MaxItems             *int64             `json:"maxItems,omitempty"`
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+var schemaNameCleaner = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+type schemaGenerator struct {
+	definitions map[string]*Schema
+	typeNames   map[reflect.Type]string
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		definitions: make(map[string]*Schema),
+		typeNames:   make(map[reflect.Type]string),
+	}
+}
+
+func (g *schemaGenerator) SchemaOf(val interface{}) *Schema {
+	if val == nil {
+		return nil
+	}
+	t, ok := val.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(val)
+	}
+	return g.schemaOfType(t)
+}
+
+func (g *schemaGenerator) schemaOfType(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		nullable = true
+	}
+	var s *Schema
+	switch {
+	case t == timeType:
+		s = &Schema{Type: "string", Format: "date-time"}
+	case t.Kind() != reflect.Struct && t.Implements(jsonMarshalerType):
+		s = &Schema{}
+	default:
+		s = g.schemaOfKind(t)
+	}
+	if nullable && s.Ref == "" {
+		s.Nullable = true
+	}
+	return s
+}
+
+func (g *schemaGenerator) schemaOfKind(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schemaOfType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaOfType(t.Elem())}
+	case reflect.Struct:
+		return g.structRef(t)
+	}
+	return &Schema{}
+}
+
+func (g *schemaGenerator) structRef(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return g.structSchema(t)
+	}
+	name, ok := g.typeNames[t]
+	if !ok {
+		name = g.uniqueName(t)
+		g.typeNames[t] = name
+		g.definitions[name] = &Schema{}
+		*g.definitions[name] = *g.structSchema(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (g *schemaGenerator) uniqueName(t reflect.Type) string {
+	name := schemaNameCleaner.ReplaceAllString(t.Name(), "_")
+	if _, ok := g.definitions[name]; !ok {
+		return name
+	}
+	pkg := t.PkgPath()
+	if idx := strings.LastIndex(pkg, "/"); idx >= 0 {
+		pkg = pkg[idx+1:]
+	}
+	name = schemaNameCleaner.ReplaceAllString(pkg, "_") + "." + name
+	base := name
+	for i := 2; ; i++ {
+		if _, ok := g.definitions[name]; !ok {
+			return name
+		}
+		name = fmt.Sprintf("%v%v", base, i)
+	}
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.addFields(s, t)
+	return s
+}
+
+func (g *schemaGenerator) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			ft := field.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(s, ft)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if _, in := fieldDocName(field); in != "body" {
+			continue
+		}
+		fieldSchema := g.schemaOfType(field.Type)
+		if applyValidationSchema(fieldSchema, field.Tag.Get(TagValidate)) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = fieldSchema
+	}
+}
+
+func (g *schemaGenerator) parametersOf(val interface{}) []*Parameter {
+	params := make([]*Parameter, 0)
+	if val == nil {
+		return params
+	}
+	t := reflect.TypeOf(val)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return params
+	}
+	return g.appendParameters(params, t)
+}
+
+func (g *schemaGenerator) appendParameters(params []*Parameter, t reflect.Type) []*Parameter {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			params = g.appendParameters(params, field.Type)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		name, in := fieldDocName(field)
+		if in == "body" {
+			continue
+		}
+		param := &Parameter{Name: name, In: in, Schema: g.schemaOfType(field.Type)}
+		param.Required = applyValidationSchema(param.Schema, field.Tag.Get(TagValidate)) || in == "path"
+		params = append(params, param)
+	}
+	return params
+}
+
+func hasBodyFields(val interface{}) bool {
+	t := reflect.TypeOf(val)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return true
+	}
+	return structHasBodyFields(t)
+}
+
+func structHasBodyFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if _, _, skip := jsonFieldName(field); skip {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+			if structHasBodyFields(field.Type) {
+				return true
+			}
+			continue
+		}
+		if _, in := fieldDocName(field); in == "body" && field.IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+func applyValidationSchema(s *Schema, tag string) (required bool) {
+	for _, rule := range parseValidationRules(tag) {
+		switch rule.name {
+		case "required":
+			required = true
+		case "regex":
+			if s.Ref == "" {
+				s.Pattern = rule.value
+			}
+		case "enum":
+			if s.Ref == "" {
+				s.Enum = strings.Split(rule.value, "|")
+			}
+		case "min", "max":
+			limit, err := strconv.ParseFloat(rule.value, 64)
+			if err != nil || s.Ref != "" {
+				continue
+			}
+			setSchemaBound(s, rule.name == "min", limit)
+		}
+	}
+	return
+}
+
+func setSchemaBound(s *Schema, isMin bool, limit float64) {
+	count := int64(limit)
+	switch s.Type {
+	case "integer", "number":
+		if isMin {
+			s.Minimum = &limit
+		} else {
+			s.Maximum = &limit
+		}
+	case "string":
+		if isMin {
+			s.MinLength = &count
+		} else {
+			s.MaxLength = &count
+		}
+	case "array":
+		if isMin {
+			s.MinItems = &count
+		} else {
+			s.MaxItems = &count
+		}
+	}
+}
+
+func jsonFieldName(field reflect.StructField) (name string, omitEmpty bool, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
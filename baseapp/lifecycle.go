@@ -0,0 +1,99 @@
+package baseapp
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sabariramc/goserverbase/log"
+)
+
+const DefaultShutdownTimeout = time.Second * 30
+
+type ShutdownHook interface {
+	Name() string
+	Shutdown(ctx context.Context) error
+}
+
+func (b *BaseApp) RegisterOnShutdown(hook ShutdownHook) {
+	b.shutdownLock.Lock()
+	defer b.shutdownLock.Unlock()
+	b.shutdownHooks = append(b.shutdownHooks, hook)
+}
+
+func (b *BaseApp) Start(ctx context.Context) error {
+	ctx = b.GetCorrelationContext(ctx, log.GetDefaultCorrelationParams(b.c.ServiceName))
+	sigCtx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+	b.shutdownLock.Lock()
+	b.server = &http.Server{Addr: b.GetPort(), Handler: b}
+	server := b.server
+	b.shutdownLock.Unlock()
+	serverErr := make(chan error, 1)
+	go func() {
+		b.log.Notice(ctx, fmt.Sprintf("Server starting at %v", server.Addr), nil)
+		serverErr <- server.ListenAndServe()
+	}()
+	select {
+	case err := <-serverErr:
+		if e.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		b.log.Error(ctx, "Server stopped unexpectedly", err)
+		shutdownCtx, cancel := b.getShutdownContext(ctx)
+		defer cancel()
+		b.Shutdown(shutdownCtx)
+		return fmt.Errorf("BaseApp.Start: %w", err)
+	case <-sigCtx.Done():
+	}
+	b.log.Notice(ctx, "Shutdown initiated", sigCtx.Err())
+	shutdownCtx, cancel := b.getShutdownContext(ctx)
+	defer cancel()
+	err := b.Shutdown(shutdownCtx)
+	if err != nil {
+		return fmt.Errorf("BaseApp.Start: %w", err)
+	}
+	return nil
+}
+
+func (b *BaseApp) getShutdownContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := b.c.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+	return context.WithTimeout(b.GetCorrelationContext(context.Background(), log.GetCorrelationParam(ctx)), timeout)
+}
+
+func (b *BaseApp) Shutdown(ctx context.Context) error {
+	b.shutdownOnce.Do(func() {
+		b.shutdownLock.Lock()
+		server := b.server
+		hooks := make([]ShutdownHook, len(b.shutdownHooks))
+		copy(hooks, b.shutdownHooks)
+		b.shutdownLock.Unlock()
+		errList := make([]error, 0)
+		if server != nil {
+			b.log.Notice(ctx, "Draining in-flight requests", nil)
+			if err := server.Shutdown(ctx); err != nil {
+				b.log.Error(ctx, "Error shutting down http server", err)
+				errList = append(errList, fmt.Errorf("http server: %w", err))
+			}
+		}
+		for i := len(hooks) - 1; i >= 0; i-- {
+			hook := hooks[i]
+			b.log.Notice(ctx, "Shutting down "+hook.Name(), nil)
+			if err := hook.Shutdown(ctx); err != nil {
+				b.log.Error(ctx, "Error shutting down "+hook.Name(), err)
+				errList = append(errList, fmt.Errorf("%v: %w", hook.Name(), err))
+			}
+		}
+		if len(errList) > 0 {
+			b.shutdownErr = fmt.Errorf("BaseApp.Shutdown: %w", e.Join(errList...))
+		}
+	})
+	return b.shutdownErr
+}
@@ -0,0 +1,135 @@
+package baseapp_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sabariramc/goserverbase/baseapp"
+	"gotest.tools/assert"
+)
+
+type shutdownRecorder struct {
+	name  string
+	order *[]string
+}
+
+func (s *shutdownRecorder) Name() string {
+	return s.name
+}
+
+func (s *shutdownRecorder) Shutdown(ctx context.Context) error {
+	*s.order = append(*s.order, s.name)
+	return nil
+}
+
+type drainCheck struct {
+	finished *atomic.Bool
+	drained  bool
+}
+
+func (d *drainCheck) Name() string {
+	return "drainCheck"
+}
+
+func (d *drainCheck) Shutdown(ctx context.Context) error {
+	d.drained = d.finished.Load()
+	return nil
+}
+
+func freePort(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer listener.Close()
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	assert.NilError(t, err)
+	return port
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(time.Second * 5)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before deadline")
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+func TestStartShutdown(t *testing.T) {
+	appConfig := *ServerTestConfig.App
+	appConfig.Host = "127.0.0.1"
+	appConfig.Port = freePort(t)
+	address := appConfig.Host + ":" + appConfig.Port
+	srv := baseapp.New(appConfig, *ServerTestConfig.Logger, ServerTestLMux, nil, nil)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var finished atomic.Bool
+	srv.GetRouter().Get("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+		finished.Store(true)
+	})
+	order := make([]string, 0)
+	drain := &drainCheck{finished: &finished}
+	srv.RegisterOnShutdown(drain)
+	srv.RegisterOnShutdown(&shutdownRecorder{name: "consumer", order: &order})
+	srv.RegisterOnShutdown(&shutdownRecorder{name: "producer", order: &order})
+	srv.RegisterOnShutdown(&shutdownRecorder{name: "mongo", order: &order})
+	ctx, cancel := context.WithCancel(GetCorrelationContext())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Start(ctx)
+	}()
+	waitFor(t, func() bool {
+		conn, err := net.Dial("tcp", address)
+		if err == nil {
+			conn.Close()
+		}
+		return err == nil
+	})
+
+	response := make(chan int, 1)
+	go func() {
+		res, err := http.Get("http://" + address + "/slow")
+		if err != nil {
+			response <- 0
+			return
+		}
+		io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+		response <- res.StatusCode
+	}()
+	<-started
+	cancel()
+	waitFor(t, func() bool {
+		conn, err := net.Dial("tcp", address)
+		if err == nil {
+			conn.Close()
+		}
+		return err != nil
+	})
+	select {
+	case <-done:
+		t.Fatal("server shutdown before the in-flight request finished")
+	default:
+	}
+	close(release)
+	select {
+	case err := <-done:
+		assert.NilError(t, err)
+	case <-time.After(time.Second * 5):
+		t.Fatal("server did not shutdown")
+	}
+	assert.Equal(t, <-response, http.StatusOK)
+	assert.Assert(t, drain.drained)
+	assert.DeepEqual(t, order, []string{"mongo", "producer", "consumer"})
+	assert.NilError(t, srv.Shutdown(context.Background()))
+	assert.DeepEqual(t, order, []string{"mongo", "producer", "consumer"})
+}
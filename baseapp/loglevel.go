@@ -0,0 +1,50 @@
+package baseapp
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/sabariramc/goserverbase/log"
+)
+
+type LogLevelRequest struct {
+	Module string `json:"module" validate:"required"`
+	Level  string `json:"level" validate:"required,enum=DEBUG|INFO|NOTICE|WARNING|ERROR|CRITICAL|ALERT|EMERGENCY"`
+	TTL    string `json:"ttl"`
+}
+
+type LogLevelResponse struct {
+	Default string               `json:"default"`
+	Modules []log.ModuleLogLevel `json:"modules"`
+}
+
+func (b *BaseApp) GetLogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	modules := log.GetModuleLogLevels()
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Module < modules[j].Module })
+	WriteJson(w, LogLevelResponse{
+		Default: log.GetLogLevelMap(log.LogLevelCode(b.lConfig.LogLevel)).LogLevelName,
+		Modules: modules,
+	})
+}
+
+func (b *BaseApp) SetLogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req LogLevelRequest
+	if !b.Bind(r, &req) {
+		return
+	}
+	var ttl time.Duration
+	if req.TTL != "" {
+		var err error
+		ttl, err = time.ParseDuration(req.TTL)
+		if err != nil || ttl < 0 {
+			b.SetHandlerError(ctx, NewValidationError([]FieldError{{Field: "ttl", Error: "should be a positive duration like 15m"}}))
+			return
+		}
+	}
+	level, _ := log.ParseLogLevel(req.Level)
+	log.SetModuleLogLevel(req.Module, level, ttl)
+	b.log.Notice(ctx, "Log level updated", req)
+	b.GetLogLevelHandler(w, r)
+}
@@ -0,0 +1,73 @@
+package baseapp_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sabariramc/goserverbase/baseapp"
+	"github.com/sabariramc/goserverbase/baseapp/test/server"
+	"github.com/sabariramc/goserverbase/log"
+	"gotest.tools/assert"
+)
+
+func allowAdmin(next http.Handler) http.Handler {
+	return next
+}
+
+func TestLogLevelEndpoint(t *testing.T) {
+	srv := server.NewServer()
+	srv.SetAdminMiddleware(allowAdmin)
+	defer log.ResetModuleLogLevel("mongo")
+	req := httptest.NewRequest(http.MethodPut, "/meta/log-level", strings.NewReader(`{"module":"mongo","level":"DEBUG","ttl":"10m"}`))
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Result().StatusCode, http.StatusOK, w.Body.String())
+	level, ok := log.GetModuleLogLevel("mongo")
+	assert.Assert(t, ok)
+	assert.Equal(t, level, log.DEBUG)
+	req = httptest.NewRequest(http.MethodGet, "/meta/log-level", nil)
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	var res baseapp.LogLevelResponse
+	assert.NilError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, len(res.Modules), 1)
+	assert.Equal(t, res.Modules[0].Module, "mongo")
+	assert.Equal(t, res.Modules[0].Level, "DEBUG")
+	assert.Assert(t, res.Modules[0].ExpiresAt != nil)
+	req = httptest.NewRequest(http.MethodPut, "/meta/log-level", strings.NewReader(`{"module":"mongo","level":"LOUD"}`))
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Result().StatusCode, http.StatusBadRequest)
+}
+
+func TestLogLevelEndpointAdminMiddleware(t *testing.T) {
+	srv := server.NewServer()
+	srv.SetAdminMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		})
+	})
+	req := httptest.NewRequest(http.MethodGet, "/meta/log-level", nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Result().StatusCode, http.StatusForbidden)
+}
+
+func TestAdminRoutesFailClosed(t *testing.T) {
+	srv := baseapp.New(*ServerTestConfig.App, *ServerTestConfig.Logger, ServerTestLMux, nil, nil)
+	defer log.ResetModuleLogLevel("mongo")
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/meta/log-level", nil),
+		httptest.NewRequest(http.MethodPut, "/meta/log-level", strings.NewReader(`{"module":"mongo","level":"DEBUG"}`)),
+		httptest.NewRequest(http.MethodGet, "/meta/logs/corr-1", nil),
+	} {
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, req)
+		assert.Equal(t, w.Result().StatusCode, http.StatusForbidden, req.URL.Path)
+	}
+	_, ok := log.GetModuleLogLevel("mongo")
+	assert.Assert(t, !ok)
+}
@@ -0,0 +1,72 @@
+package baseapp
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sabariramc/goserverbase/metrics"
+)
+
+const RoutePatternNotFound = "NOT_FOUND"
+
+var httpRequestDuration = metrics.NewHistogramVec("http_request_duration_seconds", "HTTP request latency in seconds", nil, "route", "method", "status")
+var httpRequestsInFlight = metrics.NewGauge("http_requests_in_flight", "HTTP requests currently being served")
+
+func init() {
+	metrics.MustRegister(httpRequestDuration, httpRequestsInFlight)
+}
+
+type statusResponseWriter struct {
+	status int
+	http.ResponseWriter
+}
+
+func (w *statusResponseWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusResponseWriter) Write(body []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(body)
+}
+
+func (w *statusResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (b *BaseApp) MetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		st := time.Now()
+		inFlight := httpRequestsInFlight.WithLabelValues()
+		inFlight.Inc()
+		defer inFlight.Dec()
+		statusRW := &statusResponseWriter{ResponseWriter: w}
+		defer func() {
+			status := statusRW.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			httpRequestDuration.WithLabelValues(GetRoutePattern(r), r.Method, strconv.Itoa(status)).ObserveDuration(st)
+		}()
+		next.ServeHTTP(statusRW, r)
+	})
+}
+
+func GetRoutePattern(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return RoutePatternNotFound
+	}
+	pattern := rctx.RoutePattern()
+	if pattern == "" {
+		return RoutePatternNotFound
+	}
+	return pattern
+}
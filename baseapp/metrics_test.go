@@ -0,0 +1,27 @@
+package baseapp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sabariramc/goserverbase/baseapp/test/server"
+	"gotest.tools/assert"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+	srv := server.NewServer()
+	req := httptest.NewRequest(http.MethodGet, "/service/v1/tenant/tenant_ABC4567890abc", nil)
+	srv.ServeHTTP(httptest.NewRecorder(), req)
+	req = httptest.NewRequest(http.MethodGet, "/service/v1/error/error3", nil)
+	srv.ServeHTTP(httptest.NewRecorder(), req)
+	req = httptest.NewRequest(http.MethodGet, "/meta/metrics", nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Result().StatusCode, http.StatusOK)
+	body := w.Body.String()
+	assert.Assert(t, strings.Contains(body, `http_request_duration_seconds_count{route="/service/v1/tenant/{tenantId}",method="GET",status="200"}`), body)
+	assert.Assert(t, strings.Contains(body, `http_request_duration_seconds_count{route="/service/v1/error/error3",method="GET",status="403"}`), body)
+	assert.Assert(t, strings.Contains(body, "# TYPE http_requests_in_flight gauge"), body)
+}
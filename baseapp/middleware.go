@@ -74,6 +74,10 @@ func (b *BaseApp) SendErrorResponse(ctx context.Context, w http.ResponseWriter,
 	notify := false
 	var customError *errors.CustomError
 	var httpErr *errors.HTTPError
+	var maxBytesErr *http.MaxBytesError
+	if e.As(err, &maxBytesErr) {
+		err = NewRequestTooLargeError(maxBytesErr.Limit)
+	}
 	if e.As(err, &httpErr) {
 		statusCode = httpErr.ErrorStatusCode
 		notify = httpErr.Notify
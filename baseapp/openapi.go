@@ -0,0 +1,209 @@
+package baseapp
+
+import (
+	"context"
+	"html"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sabariramc/goserverbase/errors"
+)
+
+const OpenAPIVersion = "3.0.3"
+
+type OpenAPI struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       OpenAPIInfo                      `json:"info"`
+	Servers    []OpenAPIServer                  `json:"servers,omitempty"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components OpenAPIComponents                `json:"components"`
+}
+
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type OpenAPIServer struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+type OpenAPIComponents struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+type Operation struct {
+	Tags        []string                    `json:"tags,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Parameters  []*Parameter                `json:"parameters,omitempty"`
+	RequestBody *RequestBody                `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema,omitempty"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type OpenAPIResponse struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+var chiParamPattern = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+func (b *BaseApp) RegisterRoutes(ctx context.Context, method, path string, handler *APIHandler) {
+	method = strings.ToUpper(method)
+	if err := CheckValidationRules(handler.Payload); err != nil {
+		b.log.Emergency(ctx, "Invalid validation rules on route payload", method+" "+path, err)
+	}
+	b.handler.Method(method, path, handler.Func)
+	methodMap, ok := b.docMeta.Routes[path]
+	if !ok {
+		methodMap = make(map[string]*APIHandler)
+		b.docMeta.Routes[path] = methodMap
+	}
+	methodMap[method] = handler
+	b.log.Debug(ctx, "Route registered", method+" "+path)
+}
+
+func (b *BaseApp) RegisterAPIRoute(ctx context.Context, basePath string, routes APIRoute) {
+	basePath = strings.TrimSuffix(basePath, "/")
+	for path, methodMap := range routes {
+		for method, handler := range methodMap {
+			b.RegisterRoutes(ctx, method, basePath+path, handler)
+		}
+	}
+}
+
+func (b *BaseApp) SetAPIVersion(version string) {
+	b.docMeta.Version = version
+}
+
+func (b *BaseApp) GetOpenAPISpec() *OpenAPI {
+	version := b.docMeta.Version
+	if version == "" {
+		version = "1.0.0"
+	}
+	g := newSchemaGenerator()
+	spec := &OpenAPI{
+		OpenAPI: OpenAPIVersion,
+		Info:    OpenAPIInfo{Title: b.c.ServiceName, Version: version},
+		Servers: make([]OpenAPIServer, len(b.docMeta.Server)),
+		Paths:   make(map[string]map[string]*Operation, len(b.docMeta.Routes)),
+	}
+	for i, server := range b.docMeta.Server {
+		spec.Servers[i] = OpenAPIServer{URL: server.BaseURL, Description: server.Tag}
+	}
+	errorSchema := g.SchemaOf(errors.CustomError{})
+	for path, methodMap := range b.docMeta.Routes {
+		docPath := chiParamPattern.ReplaceAllString(path, "{$1}")
+		pathItem, ok := spec.Paths[docPath]
+		if !ok {
+			pathItem = make(map[string]*Operation, len(methodMap))
+			spec.Paths[docPath] = pathItem
+		}
+		for method, handler := range methodMap {
+			pathItem[strings.ToLower(method)] = newOperation(g, path, method, handler, errorSchema)
+		}
+	}
+	spec.Components.Schemas = g.definitions
+	return spec
+}
+
+func newOperation(g *schemaGenerator, path, method string, handler *APIHandler, errorSchema *Schema) *Operation {
+	op := &Operation{
+		Tags:        handler.Tags,
+		Description: handler.Description,
+		Parameters:  make([]*Parameter, 0),
+		Responses:   make(map[string]*OpenAPIResponse),
+	}
+	op.Parameters = append(op.Parameters, g.parametersOf(handler.Payload)...)
+	for _, match := range chiParamPattern.FindAllStringSubmatch(path, -1) {
+		if !hasParameter(op.Parameters, match[1], "path") {
+			op.Parameters = append(op.Parameters, &Parameter{Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+	}
+	if handler.Payload != nil && method != http.MethodGet && method != http.MethodDelete && hasBodyFields(handler.Payload) {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{HttpContentTypeJSON: {Schema: g.SchemaOf(handler.Payload)}},
+		}
+	}
+	responseList := append(append(make([]Response, 0), handler.SuccessResponse...), handler.FailureResponse...)
+	for _, res := range responseList {
+		description := res.StatusDescription
+		if description == "" {
+			description = http.StatusText(res.StatusCode)
+		}
+		docRes := &OpenAPIResponse{Description: description}
+		if res.Response != nil {
+			docRes.Content = map[string]*MediaType{HttpContentTypeJSON: {Schema: g.SchemaOf(res.Response)}}
+		}
+		op.Responses[strconv.Itoa(res.StatusCode)] = docRes
+	}
+	op.Responses["default"] = &OpenAPIResponse{
+		Description: "Error",
+		Content:     map[string]*MediaType{HttpContentTypeJSON: {Schema: errorSchema}},
+	}
+	sort.SliceStable(op.Parameters, func(i, j int) bool {
+		if op.Parameters[i].In != op.Parameters[j].In {
+			return op.Parameters[i].In == "path"
+		}
+		return op.Parameters[i].Name < op.Parameters[j].Name
+	})
+	return op
+}
+
+func hasParameter(params []*Parameter, name, in string) bool {
+	for _, p := range params {
+		if p.Name == name && p.In == in {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *BaseApp) OpenAPIHandler(w http.ResponseWriter, r *http.Request) {
+	WriteJson(w, b.GetOpenAPISpec())
+}
+
+const swaggerUITemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8" />
+  <title>{{title}}</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: "{{specURL}}", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>`
+
+func (b *BaseApp) SwaggerUIHandler(w http.ResponseWriter, r *http.Request) {
+	page := strings.NewReplacer("{{title}}", html.EscapeString(b.c.ServiceName), "{{specURL}}", "/meta/openapi.json").Replace(swaggerUITemplate)
+	w.Header().Add(HttpHeaderContentType, "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(page))
+}
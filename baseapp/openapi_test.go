@@ -0,0 +1,88 @@
+package baseapp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sabariramc/goserverbase/baseapp"
+	"gotest.tools/assert"
+)
+
+type docAddress struct {
+	City    string `json:"city"`
+	Pincode string `json:"pincode,omitempty"`
+}
+
+type docTenant struct {
+	Name      string            `json:"name"`
+	Age       *int64            `json:"age"`
+	Tags      []string          `json:"tags"`
+	Meta      map[string]any    `json:"meta"`
+	Address   docAddress        `json:"address"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Secret    string            `json:"-"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	srv := baseapp.New(*ServerTestConfig.App, *ServerTestConfig.Logger, ServerTestLMux, nil, nil)
+	srv.AddServerHost(baseapp.DocumentServer{Tag: "local", BaseURL: "http://localhost:8080"})
+	srv.RegisterAPIRoute(context.TODO(), "/service/v2", baseapp.APIRoute{
+		"/tenant/{tenantId:[a-z0-9_]+}": {
+			http.MethodPut: &baseapp.APIHandler{
+				Func: func(w http.ResponseWriter, r *http.Request) {
+					baseapp.WriteJson(w, map[string]string{"tenantId": chi.URLParam(r, "tenantId")})
+				},
+				Description: "Update tenant",
+				Tags:        []string{"tenant"},
+				Payload:     &docTenant{},
+				SuccessResponse: []baseapp.Response{
+					{StatusCode: http.StatusOK, Response: docTenant{}},
+				},
+				FailureResponse: []baseapp.Response{
+					{StatusCode: http.StatusNotFound, StatusDescription: "Tenant not found"},
+				},
+			},
+		},
+	})
+	req := httptest.NewRequest(http.MethodPut, "/service/v2/tenant/abc_1", nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Result().StatusCode, http.StatusOK)
+
+	req = httptest.NewRequest(http.MethodGet, "/meta/openapi.json", nil)
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Result().StatusCode, http.StatusOK)
+	spec := make(map[string]any)
+	assert.NilError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+	assert.Equal(t, spec["openapi"], "3.0.3")
+	assert.DeepEqual(t, spec["servers"], []any{map[string]any{"url": "http://localhost:8080", "description": "local"}})
+	op := spec["paths"].(map[string]any)["/service/v2/tenant/{tenantId}"].(map[string]any)["put"].(map[string]any)
+	assert.DeepEqual(t, op["parameters"], []any{map[string]any{"name": "tenantId", "in": "path", "required": true, "schema": map[string]any{"type": "string"}}})
+	body := op["requestBody"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"]
+	assert.DeepEqual(t, body, map[string]any{"$ref": "#/components/schemas/docTenant"})
+	responses := op["responses"].(map[string]any)
+	assert.Equal(t, responses["404"].(map[string]any)["description"], "Tenant not found")
+	schemas := spec["components"].(map[string]any)["schemas"].(map[string]any)
+	tenant := schemas["docTenant"].(map[string]any)["properties"].(map[string]any)
+	assert.DeepEqual(t, tenant["age"], map[string]any{"type": "integer", "format": "int64", "nullable": true})
+	assert.DeepEqual(t, tenant["createdAt"], map[string]any{"type": "string", "format": "date-time"})
+	assert.DeepEqual(t, tenant["address"], map[string]any{"$ref": "#/components/schemas/docAddress"})
+	assert.DeepEqual(t, tenant["tags"], map[string]any{"type": "array", "items": map[string]any{"type": "string"}})
+	_, ok := tenant["Secret"]
+	assert.Assert(t, !ok)
+	_, ok = schemas["CustomError"]
+	assert.Assert(t, ok)
+
+	req = httptest.NewRequest(http.MethodGet, "/meta/docs", nil)
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Result().StatusCode, http.StatusOK)
+	assert.Equal(t, w.Result().Header.Get("Content-Type"), "text/html; charset=utf-8")
+}
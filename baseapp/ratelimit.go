@@ -0,0 +1,68 @@
+package baseapp
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sabariramc/goserverbase/errors"
+	"github.com/sabariramc/goserverbase/ratelimit"
+)
+
+const (
+	HttpHeaderRateLimitLimit     = "RateLimit-Limit"
+	HttpHeaderRateLimitRemaining = "RateLimit-Remaining"
+	HttpHeaderRateLimitReset     = "RateLimit-Reset"
+	HttpHeaderRetryAfter         = "Retry-After"
+)
+
+func (b *BaseApp) RateLimitMiddleware(limiters ...*ratelimit.Limiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			var strictest *ratelimit.Result
+			var denied *ratelimit.Limiter
+			for _, limiter := range limiters {
+				key, ok := limiter.Key(r)
+				if !ok {
+					continue
+				}
+				res, err := limiter.Allow(ctx, key)
+				if err != nil {
+					b.log.Error(ctx, "Rate limit store error, allowing request", err)
+					continue
+				}
+				if strictest == nil || !res.Allowed || (strictest.Allowed && res.Remaining < strictest.Remaining) {
+					strictest = &res
+				}
+				if !res.Allowed {
+					denied = limiter
+					break
+				}
+			}
+			if strictest == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			h := w.Header()
+			h.Set(HttpHeaderRateLimitLimit, strconv.Itoa(strictest.Limit))
+			h.Set(HttpHeaderRateLimitRemaining, strconv.Itoa(strictest.Remaining))
+			h.Set(HttpHeaderRateLimitReset, strconv.Itoa(ceilSeconds(strictest.Reset)))
+			if denied == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			h.Set(HttpHeaderRetryAfter, strconv.Itoa(ceilSeconds(strictest.RetryAfter)))
+			b.log.Notice(ctx, "Rate limit exceeded", map[string]any{"limiter": denied.Name(), "route": GetRoutePattern(r)})
+			b.SendErrorResponse(ctx, w, "", errors.NewHTTPClientError(http.StatusTooManyRequests, "RATE_LIMIT_EXCEEDED", "Too many requests", nil, map[string]any{
+				"limiter":    denied.Name(),
+				"retryAfter": ceilSeconds(strictest.RetryAfter),
+			}))
+		})
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,48 @@
+package baseapp_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sabariramc/goserverbase/baseapp"
+	"github.com/sabariramc/goserverbase/ratelimit"
+	"gotest.tools/assert"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	srv := baseapp.New(*ServerTestConfig.App, *ServerTestConfig.Logger, ServerTestLMux, nil, nil)
+	store := ratelimit.NewMemoryStore()
+	routeLimiter, err := ratelimit.NewSlidingWindowLimiter("route", 10, time.Minute, ratelimit.KeyByRoute, store)
+	assert.NilError(t, err)
+	ipLimiter, err := ratelimit.NewTokenBucketLimiter("ip", 2, time.Minute, ratelimit.KeyByIP, store)
+	assert.NilError(t, err)
+	srv.GetRouter().With(srv.RateLimitMiddleware(routeLimiter, ipLimiter)).Get("/tenant/{tenantId}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	for i := 1; i >= 0; i-- {
+		req := httptest.NewRequest(http.MethodGet, "/tenant/t-1", nil)
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, req)
+		assert.Equal(t, w.Code, http.StatusNoContent)
+		assert.Equal(t, w.Header().Get(baseapp.HttpHeaderRateLimitLimit), "2")
+		assert.Equal(t, w.Header().Get(baseapp.HttpHeaderRateLimitRemaining), []string{"0", "1"}[i])
+	}
+	req := httptest.NewRequest(http.MethodGet, "/tenant/t-2", nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Code, http.StatusTooManyRequests)
+	assert.Equal(t, w.Header().Get(baseapp.HttpHeaderRetryAfter), "30")
+	assert.Equal(t, w.Header().Get(baseapp.HttpHeaderRateLimitRemaining), "0")
+	var body map[string]any
+	assert.NilError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, body["errorCode"], "RATE_LIMIT_EXCEEDED")
+
+	req = httptest.NewRequest(http.MethodGet, "/tenant/t-2", nil)
+	req.RemoteAddr = "10.0.0.2:5000"
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Code, http.StatusNoContent)
+}
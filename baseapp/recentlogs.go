@@ -0,0 +1,27 @@
+package baseapp
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sabariramc/goserverbase/errors"
+	"github.com/sabariramc/goserverbase/log/logwriter"
+)
+
+type RecentLogResponse struct {
+	CorrelationId string                      `json:"correlationId"`
+	Logs          []*logwriter.JSONLogMessage `json:"logs"`
+}
+
+func (b *BaseApp) SetRingWriter(ring *logwriter.RingWriter) {
+	b.ringWriter = ring
+}
+
+func (b *BaseApp) RecentLogHandler(w http.ResponseWriter, r *http.Request) {
+	if b.ringWriter == nil {
+		b.SetHandlerError(r.Context(), errors.NewHTTPClientError(http.StatusNotFound, "RECENT_LOGS_DISABLED", "Recent log buffer is not enabled", nil, nil))
+		return
+	}
+	correlationId := chi.URLParam(r, "correlationId")
+	WriteJson(w, RecentLogResponse{CorrelationId: correlationId, Logs: b.ringWriter.GetLogs(correlationId)})
+}
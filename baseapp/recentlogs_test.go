@@ -0,0 +1,57 @@
+package baseapp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sabariramc/goserverbase/baseapp"
+	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/log/logwriter"
+	"gotest.tools/assert"
+)
+
+func TestRecentLogEndpoint(t *testing.T) {
+	srv := baseapp.New(*ServerTestConfig.App, *ServerTestConfig.Logger, ServerTestLMux, nil, nil)
+	srv.SetAdminMiddleware(allowAdmin)
+	req := httptest.NewRequest(http.MethodGet, "/meta/logs/corr-1", nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Result().StatusCode, http.StatusNotFound)
+
+	hostParams := log.HostParams{ServiceName: ServerTestConfig.App.ServiceName}
+	ring := logwriter.NewRingWriter(hostParams, 100)
+	lConfig := *ServerTestConfig.Logger
+	lConfig.LogLevel = int(log.ERROR)
+	srv = baseapp.New(*ServerTestConfig.App, lConfig, log.NewDefaultLogMux(ring), nil, nil)
+	srv.SetRingWriter(ring)
+	srv.SetAdminMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("x-admin") == "" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+	ctx := context.WithValue(context.Background(), log.ContextKeyCorrelation, &log.CorrelationParam{CorrelationId: "corr-1"})
+	srv.GetLogger().Debug(ctx, "cache miss", nil)
+
+	req = httptest.NewRequest(http.MethodGet, "/meta/logs/corr-1", nil)
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Result().StatusCode, http.StatusForbidden)
+
+	req.Header.Set("x-admin", "true")
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Result().StatusCode, http.StatusOK)
+	var res baseapp.RecentLogResponse
+	assert.NilError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, res.CorrelationId, "corr-1")
+	assert.Equal(t, len(res.Logs), 1)
+	assert.Equal(t, res.Logs[0].ShortMessage, "cache miss")
+	assert.Equal(t, res.Logs[0].Level, "DEBUG")
+}
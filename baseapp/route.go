@@ -5,11 +5,13 @@ import (
 	"net/http"
 
 	"github.com/sabariramc/goserverbase/errors"
+	"github.com/sabariramc/goserverbase/metrics"
 )
 
 type APIDocumentation struct {
-	Server []DocumentServer
-	Routes APIRoute
+	Version string
+	Server  []DocumentServer
+	Routes  APIRoute
 }
 
 type DocumentServer struct {
@@ -62,8 +64,16 @@ func HealthCheck(w http.ResponseWriter, r *http.Request) {
 }
 
 func (b *BaseApp) SetupRouter(ctx context.Context) {
-	b.handler.Use(b.SetContextMiddleware, b.RequestTimerMiddleware, b.LogRequestResponseMiddleware, b.HandleExceptionMiddleware)
+	b.handler.Use(b.MetricsMiddleware, b.TracingMiddleware, b.SetContextMiddleware, b.SecurityHeadersMiddleware, b.CORSMiddleware, b.RequestSizeLimitMiddleware, b.RequestTimerMiddleware, b.LogRequestResponseMiddleware, b.HandleExceptionMiddleware)
 	b.handler.NotFound(NotFound())
 	b.handler.MethodNotAllowed(MethodNotAllowed())
 	b.handler.Get("/meta/health", HealthCheck)
+	b.handler.Get("/meta/live", b.LivenessHandler)
+	b.handler.Get("/meta/ready", b.ReadinessHandler)
+	b.handler.Get("/meta/openapi.json", b.OpenAPIHandler)
+	b.handler.Get("/meta/docs", b.SwaggerUIHandler)
+	b.handler.Get("/meta/metrics", metrics.Handler())
+	b.handler.With(b.AdminMiddleware).Get("/meta/log-level", b.GetLogLevelHandler)
+	b.handler.With(b.AdminMiddleware).Put("/meta/log-level", b.SetLogLevelHandler)
+	b.handler.With(b.AdminMiddleware).Get("/meta/logs/{correlationId}", b.RecentLogHandler)
 }
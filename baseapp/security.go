@@ -0,0 +1,154 @@
+package baseapp
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sabariramc/goserverbase/errors"
+)
+
+const DefaultFrameOptions = "DENY"
+
+var defaultCORSMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+func (b *BaseApp) CORSMiddleware(next http.Handler) http.Handler {
+	c := b.c.CORS
+	if c == nil {
+		return next
+	}
+	origins := make([]string, 0, len(c.AllowedOrigins))
+	for _, origin := range c.AllowedOrigins {
+		origins = append(origins, strings.ToLower(origin))
+	}
+	methods := c.AllowedMethods
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	allowMethods := strings.Join(methods, ", ")
+	allowHeaders := strings.Join(c.AllowedHeaders, ", ")
+	exposeHeaders := strings.Join(c.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(c.MaxAge.Seconds()))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		h := w.Header()
+		h.Add("Vary", "Origin")
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+		allowed, wildcard := matchOrigin(origins, strings.ToLower(origin))
+		if !allowed {
+			if preflight {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+		if wildcard {
+			h.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			h.Set("Access-Control-Allow-Origin", origin)
+		}
+		if c.AllowCredentials {
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+		if !preflight {
+			if exposeHeaders != "" {
+				h.Set("Access-Control-Expose-Headers", exposeHeaders)
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+		h.Add("Vary", "Access-Control-Request-Method")
+		h.Add("Vary", "Access-Control-Request-Headers")
+		h.Set("Access-Control-Allow-Methods", allowMethods)
+		if allowHeaders != "" {
+			h.Set("Access-Control-Allow-Headers", allowHeaders)
+		} else if requested := r.Header.Get("Access-Control-Request-Headers"); requested != "" {
+			h.Set("Access-Control-Allow-Headers", requested)
+		}
+		if c.MaxAge > 0 {
+			h.Set("Access-Control-Max-Age", maxAge)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func matchOrigin(allowed []string, origin string) (ok bool, wildcard bool) {
+	for _, pattern := range allowed {
+		if pattern == "*" {
+			return true, true
+		}
+		prefix, suffix, found := strings.Cut(pattern, "*")
+		if !found {
+			if pattern == origin {
+				return true, false
+			}
+			continue
+		}
+		if len(origin) > len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+			if !strings.ContainsAny(origin[len(prefix):len(origin)-len(suffix)], "/:") {
+				return true, false
+			}
+		}
+	}
+	return false, false
+}
+
+func (b *BaseApp) SecurityHeadersMiddleware(next http.Handler) http.Handler {
+	c := b.c.SecurityHeaders
+	if c == nil {
+		return next
+	}
+	hsts := ""
+	if c.HSTSMaxAge > 0 {
+		hsts = fmt.Sprintf("max-age=%d", int(c.HSTSMaxAge.Seconds()))
+		if c.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+		if c.HSTSPreload {
+			hsts += "; preload"
+		}
+	}
+	frameOptions := c.FrameOptions
+	if frameOptions == "" {
+		frameOptions = DefaultFrameOptions
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		h.Set("X-Content-Type-Options", "nosniff")
+		h.Set("X-Frame-Options", frameOptions)
+		if hsts != "" {
+			h.Set("Strict-Transport-Security", hsts)
+		}
+		if c.ContentSecurityPolicy != "" {
+			h.Set("Content-Security-Policy", c.ContentSecurityPolicy)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (b *BaseApp) RequestSizeLimitMiddleware(next http.Handler) http.Handler {
+	limit := b.c.MaxRequestBodySize
+	if limit <= 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > limit {
+			b.SendErrorResponse(r.Context(), w, "", NewRequestTooLargeError(limit))
+			return
+		}
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func NewRequestTooLargeError(limit int64) *errors.HTTPError {
+	return errors.NewHTTPClientError(http.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE", "Request body too large", nil, map[string]any{"maxBytes": limit})
+}
@@ -0,0 +1,108 @@
+package baseapp_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sabariramc/goserverbase/baseapp"
+	"github.com/sabariramc/goserverbase/config"
+	"gotest.tools/assert"
+)
+
+type sizeLimitRequest struct {
+	Name string `json:"name"`
+}
+
+func newSecuredApp() *baseapp.BaseApp {
+	appConfig := *ServerTestConfig.App
+	appConfig.CORS = &config.CORSConfig{
+		AllowedOrigins:   []string{"https://app.example.com", "https://*.partner.com"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		ExposedHeaders:   []string{"x-correlation-id"},
+		AllowCredentials: true,
+		MaxAge:           time.Hour,
+	}
+	appConfig.SecurityHeaders = &config.SecurityHeadersConfig{HSTSMaxAge: time.Hour * 24 * 365, HSTSIncludeSubdomains: true, ContentSecurityPolicy: "default-src 'self'"}
+	appConfig.MaxRequestBodySize = 32
+	srv := baseapp.New(appConfig, *ServerTestConfig.Logger, ServerTestLMux, nil, nil)
+	srv.GetRouter().Post("/tenant", func(w http.ResponseWriter, r *http.Request) {
+		var req sizeLimitRequest
+		if !srv.Bind(r, &req) {
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return srv
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	srv := newSecuredApp()
+	req := httptest.NewRequest(http.MethodOptions, "/tenant", nil)
+	req.Header.Set("Origin", "https://shop.partner.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Code, http.StatusNoContent)
+	assert.Equal(t, w.Header().Get("Access-Control-Allow-Origin"), "https://shop.partner.com")
+	assert.Equal(t, w.Header().Get("Access-Control-Allow-Credentials"), "true")
+	assert.Equal(t, w.Header().Get("Access-Control-Allow-Headers"), "Authorization, Content-Type")
+	assert.Equal(t, w.Header().Get("Access-Control-Max-Age"), "3600")
+	assert.Assert(t, strings.Contains(w.Header().Get("Access-Control-Allow-Methods"), http.MethodPost))
+
+	req = httptest.NewRequest(http.MethodPost, "/tenant", strings.NewReader(`{"name":"acme"}`))
+	req.Header.Set("Origin", "https://app.example.com")
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Code, http.StatusNoContent)
+	assert.Equal(t, w.Header().Get("Access-Control-Allow-Origin"), "https://app.example.com")
+	assert.Equal(t, w.Header().Get("Access-Control-Expose-Headers"), "x-correlation-id")
+
+	for _, origin := range []string{"https://evil.com", "https://a.b/.partner.com", "https://partner.com"} {
+		req = httptest.NewRequest(http.MethodOptions, "/tenant", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		w = httptest.NewRecorder()
+		srv.ServeHTTP(w, req)
+		assert.Equal(t, w.Header().Get("Access-Control-Allow-Origin"), "", origin)
+	}
+}
+
+func TestCORSRejectsWildcardWithCredentials(t *testing.T) {
+	cors := &config.CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}
+	assert.ErrorContains(t, cors.Validate(), "AllowCredentials")
+	appConfig := *ServerTestConfig.App
+	appConfig.CORS = cors
+	defer func() {
+		assert.Assert(t, recover() != nil)
+	}()
+	baseapp.New(appConfig, *ServerTestConfig.Logger, ServerTestLMux, nil, nil)
+}
+
+func TestSecurityHeadersAndSizeLimit(t *testing.T) {
+	srv := newSecuredApp()
+	req := httptest.NewRequest(http.MethodPost, "/tenant", strings.NewReader(`{"name":"acme"}`))
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Code, http.StatusNoContent)
+	assert.Equal(t, w.Header().Get("Strict-Transport-Security"), "max-age=31536000; includeSubDomains")
+	assert.Equal(t, w.Header().Get("Content-Security-Policy"), "default-src 'self'")
+	assert.Equal(t, w.Header().Get("X-Content-Type-Options"), "nosniff")
+	assert.Equal(t, w.Header().Get("X-Frame-Options"), "DENY")
+
+	body := `{"name":"` + strings.Repeat("a", 64) + `"}`
+	req = httptest.NewRequest(http.MethodPost, "/tenant", strings.NewReader(body))
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Code, http.StatusRequestEntityTooLarge)
+	assert.Assert(t, strings.Contains(w.Body.String(), "REQUEST_TOO_LARGE"), w.Body.String())
+
+	req = httptest.NewRequest(http.MethodPost, "/tenant", io.MultiReader(strings.NewReader(body)))
+	req.ContentLength = -1
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	assert.Equal(t, w.Code, http.StatusRequestEntityTooLarge)
+}
@@ -0,0 +1,33 @@
+package baseapp
+
+import (
+	"net/http"
+
+	"github.com/sabariramc/goserverbase/trace"
+)
+
+func (b *BaseApp) TracingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := trace.Extract(r.Context(), trace.HeaderCarrier(r.Header))
+		ctx, span := trace.Start(ctx, r.Method, trace.SpanKindServer,
+			trace.String("http.request.method", r.Method),
+			trace.String("url.path", r.URL.Path),
+			trace.String("server.address", r.Host),
+		)
+		defer span.End()
+		statusRW := &statusResponseWriter{ResponseWriter: w}
+		defer func() {
+			status := statusRW.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			route := GetRoutePattern(r)
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(trace.String("http.route", route), trace.Int("http.response.status_code", status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(trace.StatusError, http.StatusText(status))
+			}
+		}()
+		next.ServeHTTP(statusRW, r.WithContext(ctx))
+	})
+}
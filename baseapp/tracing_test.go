@@ -0,0 +1,27 @@
+package baseapp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sabariramc/goserverbase/baseapp/test/server"
+	"github.com/sabariramc/goserverbase/trace"
+	"gotest.tools/assert"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	exporter := trace.NewInMemoryExporter()
+	trace.SetTracer(trace.NewTracer("test", trace.NewSimpleSpanProcessor(exporter)))
+	defer trace.SetTracer(trace.NewTracer("", nil))
+	srv := server.NewServer()
+	req := httptest.NewRequest(http.MethodGet, "/service/v1/tenant/tenant_ABC4567890abc", nil)
+	req.Header.Set(trace.HeaderTraceParent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	srv.ServeHTTP(httptest.NewRecorder(), req)
+	spans := exporter.GetSpans()
+	assert.Equal(t, len(spans), 1)
+	assert.Equal(t, spans[0].Name, "GET /service/v1/tenant/{tenantId}")
+	assert.Equal(t, spans[0].Kind, trace.SpanKindServer)
+	assert.Equal(t, spans[0].SpanContext.TraceID.String(), "4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.Equal(t, spans[0].ParentSpanID.String(), "00f067aa0ba902b7")
+}
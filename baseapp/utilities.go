@@ -51,9 +51,11 @@ func (b *BaseApp) PrintRequest(ctx context.Context, r *http.Request) {
 		body := r.Body
 		defer body.Close()
 		blobBody, _ := ioutil.ReadAll(body)
-		data := make(map[string]any)
-		json.Unmarshal(blobBody, &data)
 		r.Body = io.NopCloser(bytes.NewReader(blobBody))
+		var data any
+		if err := json.Unmarshal(blobBody, &data); err != nil {
+			data = string(blobBody)
+		}
 		b.log.Debug(ctx, "Request Body", data)
 	}
 	for key, value := range popList {
@@ -0,0 +1,330 @@
+package baseapp
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/sabariramc/goserverbase/errors"
+)
+
+const (
+	ErrorCodeValidation = "VALIDATION_ERROR"
+	TagValidate         = "validate"
+	TagQuery            = "schema"
+	TagPath             = "path"
+)
+
+type FieldError struct {
+	Field string `json:"field"`
+	Error string `json:"error"`
+}
+
+type validationRule struct {
+	name  string
+	value string
+	limit float64
+	regex *regexp.Regexp
+}
+
+type fieldValidation struct {
+	index    int
+	name     string
+	embedded bool
+	optional bool
+	required bool
+	rules    []validationRule
+}
+
+type structValidation struct {
+	fields []fieldValidation
+	err    error
+}
+
+var structValidationCache sync.Map
+
+func NewValidationError(fieldErrors []FieldError) *errors.HTTPError {
+	return errors.NewHTTPClientError(http.StatusBadRequest, ErrorCodeValidation, "Invalid request", nil, fieldErrors)
+}
+
+func ValidateStruct(val interface{}) error {
+	fieldErrors := make([]FieldError, 0)
+	if err := validateValue(reflect.ValueOf(val), "", &fieldErrors); err != nil {
+		return fmt.Errorf("baseapp.ValidateStruct: %w", err)
+	}
+	if len(fieldErrors) > 0 {
+		return NewValidationError(fieldErrors)
+	}
+	return nil
+}
+
+// CheckValidationRules parses the validate tags of val and every struct reachable from it, reporting unknown rules and malformed values
+func CheckValidationRules(val interface{}) error {
+	if val == nil {
+		return nil
+	}
+	if err := checkTypeRules(reflect.TypeOf(val), make(map[reflect.Type]bool)); err != nil {
+		return fmt.Errorf("baseapp.CheckValidationRules: %w", err)
+	}
+	return nil
+}
+
+func checkTypeRules(t reflect.Type, seen map[reflect.Type]bool) error {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == timeType || seen[t] {
+		return nil
+	}
+	seen[t] = true
+	sv := getStructValidation(t)
+	if sv.err != nil {
+		return sv.err
+	}
+	for _, f := range sv.fields {
+		if err := checkTypeRules(t.Field(f.index).Type, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getStructValidation(t reflect.Type) *structValidation {
+	if sv, ok := structValidationCache.Load(t); ok {
+		return sv.(*structValidation)
+	}
+	sv, _ := structValidationCache.LoadOrStore(t, compileStructValidation(t))
+	return sv.(*structValidation)
+}
+
+func compileStructValidation(t reflect.Type) *structValidation {
+	sv := &structValidation{fields: make([]fieldValidation, 0, t.NumField())}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("json") == "-" && field.Tag.Get(TagQuery) == "" && field.Tag.Get(TagPath) == "" {
+			continue
+		}
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			sv.fields = append(sv.fields, fieldValidation{index: i, embedded: true})
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		rules, err := compileValidationRules(field.Tag.Get(TagValidate))
+		if err != nil {
+			sv.err = fmt.Errorf("%v.%v: %w", t, field.Name, err)
+			return sv
+		}
+		name, _ := fieldDocName(field)
+		_, omitEmpty, _ := jsonFieldName(field)
+		fv := fieldValidation{index: i, name: name, optional: omitEmpty || field.Type.Kind() == reflect.Pointer, rules: rules}
+		for _, rule := range rules {
+			if rule.name == "required" {
+				fv.required = true
+			}
+		}
+		sv.fields = append(sv.fields, fv)
+	}
+	return sv
+}
+
+func parseValidationRules(tag string) []validationRule {
+	rules := make([]validationRule, 0)
+	for tag != "" {
+		var part string
+		if strings.HasPrefix(tag, "regex=") {
+			part, tag = tag, ""
+		} else if idx := strings.Index(tag, ","); idx >= 0 {
+			part, tag = tag[:idx], tag[idx+1:]
+		} else {
+			part, tag = tag, ""
+		}
+		name, value, _ := strings.Cut(part, "=")
+		rules = append(rules, validationRule{name: strings.TrimSpace(name), value: value})
+	}
+	return rules
+}
+
+func compileValidationRules(tag string) ([]validationRule, error) {
+	rules := parseValidationRules(tag)
+	for i := range rules {
+		rule := &rules[i]
+		var err error
+		switch rule.name {
+		case "required", "", "enum":
+		case "min", "max":
+			rule.limit, err = strconv.ParseFloat(rule.value, 64)
+		case "regex":
+			rule.regex, err = regexp.Compile(rule.value)
+		default:
+			return nil, fmt.Errorf("unknown validation rule `%v`", rule.name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v rule `%v`: %w", rule.name, rule.value, err)
+		}
+	}
+	return rules, nil
+}
+func fieldDocName(field reflect.StructField) (name string, in string) {
+	if name = field.Tag.Get(TagPath); name != "" {
+		return name, "path"
+	}
+	if name = field.Tag.Get(TagQuery); name != "" && name != "-" {
+		return name, "query"
+	}
+	name, _, _ = jsonFieldName(field)
+	return name, "body"
+}
+
+func joinFieldPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func validateValue(v reflect.Value, path string, fieldErrors *[]FieldError) error {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return nil
+		}
+		sv := getStructValidation(v.Type())
+		if sv.err != nil {
+			return sv.err
+		}
+		for _, field := range sv.fields {
+			fv := v.Field(field.index)
+			if field.embedded {
+				if err := validateValue(fv, path, fieldErrors); err != nil {
+					return err
+				}
+				continue
+			}
+			fieldPath := joinFieldPath(path, field.name)
+			if applyRules(fv, fieldPath, &field, fieldErrors) {
+				if err := validateValue(fv, fieldPath, fieldErrors); err != nil {
+					return err
+				}
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := validateValue(v.Index(i), fmt.Sprintf("%v[%v]", path, i), fieldErrors); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := validateValue(iter.Value(), joinFieldPath(path, fmt.Sprint(iter.Key().Interface())), fieldErrors); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func applyRules(v reflect.Value, path string, field *fieldValidation, fieldErrors *[]FieldError) bool {
+	if isEmptyValue(v) {
+		if field.required {
+			*fieldErrors = append(*fieldErrors, FieldError{Field: path, Error: "is required"})
+			return false
+		}
+		if field.optional {
+			return false
+		}
+	}
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	for _, rule := range field.rules {
+		var msg string
+		switch rule.name {
+		case "min", "max":
+			msg = checkBound(v, rule)
+		case "regex":
+			msg = checkRegex(v, rule)
+		case "enum":
+			msg = checkEnum(v, rule.value)
+		}
+		if msg != "" {
+			*fieldErrors = append(*fieldErrors, FieldError{Field: path, Error: msg})
+			return false
+		}
+	}
+	return true
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	case reflect.Invalid:
+		return true
+	}
+	return v.IsZero()
+}
+
+func checkBound(v reflect.Value, rule validationRule) string {
+	var actual float64
+	var unit string
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		actual = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		actual = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		actual = v.Float()
+	case reflect.String:
+		actual = float64(utf8.RuneCountInString(v.String()))
+		unit = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		actual = float64(v.Len())
+		unit = " items"
+	default:
+		return ""
+	}
+	if rule.name == "min" && actual < rule.limit {
+		return fmt.Sprintf("must be at least %v%v", rule.value, unit)
+	}
+	if rule.name == "max" && actual > rule.limit {
+		return fmt.Sprintf("must be at most %v%v", rule.value, unit)
+	}
+	return ""
+}
+
+func checkRegex(v reflect.Value, rule validationRule) string {
+	if v.Kind() != reflect.String {
+		return ""
+	}
+	if !rule.regex.MatchString(v.String()) {
+		return "must match pattern " + rule.value
+	}
+	return ""
+}
+
+func checkEnum(v reflect.Value, values string) string {
+	actual := fmt.Sprint(v.Interface())
+	for _, allowed := range strings.Split(values, "|") {
+		if actual == allowed {
+			return ""
+		}
+	}
+	return "must be one of " + strings.ReplaceAll(values, "|", ", ")
+}
@@ -1,15 +1,54 @@
 package config
 
+import (
+	"fmt"
+	"time"
+)
+
 type MongoCSFLEConfig struct {
 	KeyVaultNamespace string
 	MasterKeyARN      string
 }
 
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// Validate rejects a wildcard origin combined with credentials, it would let any site make credentialed requests
+func (c *CORSConfig) Validate() error {
+	if c == nil || !c.AllowCredentials {
+		return nil
+	}
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			return fmt.Errorf("CORSConfig.Validate: AllowedOrigins \"*\" cannot be used with AllowCredentials, list the trusted origins")
+		}
+	}
+	return nil
+}
+
+type SecurityHeadersConfig struct {
+	HSTSMaxAge            time.Duration
+	HSTSIncludeSubdomains bool
+	HSTSPreload           bool
+	ContentSecurityPolicy string
+	FrameOptions          string
+}
+
 type ServerConfig struct {
-	Host        string
-	Port        string
-	ServiceName string
-	Debug       bool
+	Host               string
+	Port               string
+	ServiceName        string
+	Debug              bool
+	ShutdownTimeout    time.Duration
+	CORS               *CORSConfig
+	SecurityHeaders    *SecurityHeadersConfig
+	MaxRequestBodySize int64
 }
 
 type RuntimeConfig struct {
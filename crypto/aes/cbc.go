@@ -70,7 +70,7 @@ func (a *AESCBC) Encrypt(ctx context.Context, plainBlob []byte) ([]byte, error)
 }
 
 func (a *AESCBC) EncryptString(ctx context.Context, plainText string) (string, error) {
-	a.log.Debug(ctx, "Plain Text", plainText)
+	a.log.Debug(ctx, "Plain Text", map[string]any{"plaintext": plainText})
 	blobRes, err := a.Encrypt(ctx, []byte(plainText))
 	if err != nil {
 		return "", fmt.Errorf("AESCBC.EncryptString: %w", err)
@@ -109,6 +109,6 @@ func (a *AESCBC) DecryptString(ctx context.Context, encryptedText string) (strin
 		return "", fmt.Errorf("AESCBC.DecryptString: %w", err)
 	}
 	res := string(blobRes)
-	a.log.Debug(ctx, "DecryptedString", res)
+	a.log.Debug(ctx, "DecryptedString", map[string]any{"plaintext": res})
 	return res, nil
 }
@@ -8,6 +8,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/sabariramc/goserverbase/crypto"
 	"github.com/sabariramc/goserverbase/crypto/aes"
+	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/log/logtest"
 	"gotest.tools/assert"
 )
 
@@ -50,3 +52,26 @@ func TestCBCV2(t *testing.T) {
 	assert.NilError(t, err)
 	assert.Equal(t, data, deres)
 }
+
+func TestCBCDoesNotLogPlainText(t *testing.T) {
+	data := "card holder 4111111111111111"
+	ctx := context.TODO()
+	logger, capture := logtest.NewTestLogger(t)
+	key := strings.Replace(uuid.New().String(), "-", "", -1)
+	v1, err := aes.NewAESCBCPKCS7(ctx, logger, key)
+	assert.NilError(t, err)
+	v2, err := aes.NewAESCBCV2PKCS7(ctx, logger, key, []byte(key)[:16])
+	assert.NilError(t, err)
+	for _, chiper := range []crypto.Cipher{v1, v2} {
+		res, err := chiper.EncryptString(ctx, data)
+		assert.NilError(t, err)
+		deres, err := chiper.DecryptString(ctx, res)
+		assert.NilError(t, err)
+		assert.Equal(t, data, deres)
+	}
+	assert.Equal(t, len(capture.Find(log.DEBUG, "^Plain Text$")), 2)
+	assert.Equal(t, len(capture.Find(log.DEBUG, "^DecryptedString$")), 2)
+	for _, entry := range capture.Entries() {
+		assert.Assert(t, !strings.Contains(entry.FullMessage, data), entry.FullMessage)
+	}
+}
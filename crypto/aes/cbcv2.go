@@ -59,7 +59,7 @@ func (a *AESCBCV2) Encrypt(ctx context.Context, plainBlob []byte) ([]byte, error
 }
 
 func (a *AESCBCV2) EncryptString(ctx context.Context, plainText string) (string, error) {
-	a.log.Debug(ctx, "Plain Text", plainText)
+	a.log.Debug(ctx, "Plain Text", map[string]any{"plaintext": plainText})
 	byteRes, err := a.Encrypt(ctx, []byte(plainText))
 	if err != nil {
 		return "", fmt.Errorf("AESCBCV2.EncryptString: %w", err)
@@ -92,7 +92,7 @@ func (a *AESCBCV2) DecryptString(ctx context.Context, encryptedText string) (str
 		return "", fmt.Errorf("AESCBCV2.DecryptString: %w", err)
 	}
 	res := string(blobRes)
-	a.log.Debug(ctx, "DecryptedString", res)
+	a.log.Debug(ctx, "DecryptedString", map[string]any{"plaintext": res})
 	return res, nil
 }
 
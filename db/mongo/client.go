@@ -9,6 +9,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 type Mongo struct {
@@ -30,7 +31,7 @@ func New(ctx context.Context, logger *log.Logger, c Config, opts ...*options.Cli
 }
 
 func NewWithClient(ctx context.Context, logger *log.Logger, c Config, client *mongo.Client) *Mongo {
-	return &Mongo{Client: client, log: logger, c: &c}
+	return &Mongo{Client: client, log: logger.NewResourceLogger("mongo"), c: &c}
 }
 
 func NewMongoClient(ctx context.Context, logger *log.Logger, c *Config, opts ...*options.ClientOptions) (*mongo.Client, error) {
@@ -40,7 +41,7 @@ func NewMongoClient(ctx context.Context, logger *log.Logger, c *Config, opts ...
 	connectionOptions.SetMinPoolSize(c.MinConnectionPool)
 	connectionOptions.SetMaxPoolSize(c.MaxConnectionPool)
 	connectionOptions.SetMaxConnIdleTime(time.Minute * 5)
-	opts = append(opts, connectionOptions)
+	opts = append(append([]*options.ClientOptions{options.Client().SetMonitor(NewCommandMonitor())}, opts...), connectionOptions)
 	client, err := mongo.Connect(ctx, opts...)
 	if err != nil {
 		logger.Error(ctx, "Error creating mongo connection", err)
@@ -65,3 +66,24 @@ func (m *Mongo) Database(name string, opts ...*options.DatabaseOptions) *Databas
 	db := m.Client.Database(name, opts...)
 	return &Database{Database: db, log: m.log}
 }
+
+func (m *Mongo) HealthCheck(ctx context.Context) error {
+	err := m.Client.Ping(ctx, readpref.Primary())
+	if err != nil {
+		return fmt.Errorf("Mongo.HealthCheck: %w", err)
+	}
+	return nil
+}
+
+func (m *Mongo) Name() string {
+	return "Mongo"
+}
+
+func (m *Mongo) Shutdown(ctx context.Context) error {
+	err := m.Client.Disconnect(ctx)
+	if err != nil {
+		m.log.Error(ctx, "Error disconnecting mongo client", err)
+		return fmt.Errorf("Mongo.Shutdown: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sabariramc/goserverbase/metrics"
+	"github.com/sabariramc/goserverbase/trace"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+const (
+	metricStatusSuccess = "success"
+	metricStatusFailure = "failure"
+)
+
+var commandDuration = metrics.NewHistogramVec("mongo_command_duration_seconds", "Mongo command duration in seconds", nil, "command", "status")
+
+func init() {
+	metrics.MustRegister(commandDuration)
+}
+
+func NewCommandMonitor() *event.CommandMonitor {
+	var spans sync.Map
+	spanKey := func(connectionID string, requestID int64) string {
+		return fmt.Sprintf("%v:%v", connectionID, requestID)
+	}
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			if !trace.SpanContextFromContext(ctx).IsValid() {
+				return
+			}
+			_, span := trace.Start(ctx, e.CommandName+" "+e.DatabaseName, trace.SpanKindClient, trace.String("db.system", "mongodb"), trace.String("db.name", e.DatabaseName), trace.String("db.operation", e.CommandName))
+			spans.Store(spanKey(e.ConnectionID, e.RequestID), span)
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			commandDuration.WithLabelValues(e.CommandName, metricStatusSuccess).Observe(time.Duration(e.DurationNanos).Seconds())
+			if span, ok := spans.LoadAndDelete(spanKey(e.ConnectionID, e.RequestID)); ok {
+				span.(*trace.Span).End()
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			commandDuration.WithLabelValues(e.CommandName, metricStatusFailure).Observe(time.Duration(e.DurationNanos).Seconds())
+			if span, ok := spans.LoadAndDelete(spanKey(e.ConnectionID, e.RequestID)); ok {
+				span.(*trace.Span).RecordError(errors.New(e.Failure))
+				span.(*trace.Span).End()
+			}
+		},
+	}
+}
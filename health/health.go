@@ -0,0 +1,158 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultTimeout = time.Second * 2
+
+	StatusUp       = "UP"
+	StatusDegraded = "DEGRADED"
+	StatusDown     = "DOWN"
+)
+
+type Checker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) HealthCheck(ctx context.Context) error {
+	return f(ctx)
+}
+
+type CheckConfig struct {
+	Critical bool
+	Liveness bool
+	Timeout  time.Duration
+	CacheTTL time.Duration
+}
+
+type CheckResult struct {
+	Status    string    `json:"status"`
+	Critical  bool      `json:"critical"`
+	LatencyMs int64     `json:"latencyMs"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+	Cached    bool      `json:"cached"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+type check struct {
+	name    string
+	checker Checker
+	config  CheckConfig
+	lock    sync.Mutex
+	last    *CheckResult
+}
+
+type Registry struct {
+	lock   sync.RWMutex
+	checks []*check
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) Register(name string, checker Checker, config CheckConfig) {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for i, c := range r.checks {
+		if c.name == name {
+			r.checks[i] = &check{name: name, checker: checker, config: config}
+			return
+		}
+	}
+	r.checks = append(r.checks, &check{name: name, checker: checker, config: config})
+	sort.Slice(r.checks, func(i, j int) bool { return r.checks[i].name < r.checks[j].name })
+}
+
+func (r *Registry) Liveness(ctx context.Context) Report {
+	return r.run(ctx, true)
+}
+
+func (r *Registry) Readiness(ctx context.Context) Report {
+	return r.run(ctx, false)
+}
+
+func (r *Registry) run(ctx context.Context, liveness bool) Report {
+	r.lock.RLock()
+	checks := make([]*check, 0, len(r.checks))
+	for _, c := range r.checks {
+		if !liveness || c.config.Liveness {
+			checks = append(checks, c)
+		}
+	}
+	r.lock.RUnlock()
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c *check) {
+			defer wg.Done()
+			results[i] = c.run(ctx)
+		}(i, c)
+	}
+	wg.Wait()
+	report := Report{Status: StatusUp, Checks: make(map[string]CheckResult, len(checks))}
+	for i, c := range checks {
+		report.Checks[c.name] = results[i]
+		if results[i].Status == StatusUp {
+			continue
+		}
+		if c.config.Critical {
+			report.Status = StatusDown
+		} else if report.Status == StatusUp {
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+func (c *check) run(ctx context.Context) CheckResult {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.last != nil && c.config.CacheTTL > 0 && time.Since(c.last.CheckedAt) < c.config.CacheTTL {
+		res := *c.last
+		res.Cached = true
+		return res
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+	st := time.Now()
+	errChan := make(chan error, 1)
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				errChan <- fmt.Errorf("health check panic: %v", rec)
+			}
+		}()
+		errChan <- c.checker.HealthCheck(ctx)
+	}()
+	var err error
+	select {
+	case err = <-errChan:
+	case <-ctx.Done():
+		err = fmt.Errorf("health check timed out after %v", c.config.Timeout)
+	}
+	res := CheckResult{Status: StatusUp, Critical: c.config.Critical, LatencyMs: time.Since(st).Milliseconds(), CheckedAt: st}
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+	c.last = &res
+	return res
+}
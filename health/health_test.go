@@ -0,0 +1,57 @@
+package health_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sabariramc/goserverbase/health"
+	"gotest.tools/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := health.NewRegistry()
+	var calls atomic.Int32
+	registry.Register("db", health.CheckerFunc(func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}), health.CheckConfig{Critical: true, Liveness: true, CacheTTL: time.Minute})
+	var cacheDown atomic.Bool
+	registry.Register("cache", health.CheckerFunc(func(ctx context.Context) error {
+		if cacheDown.Load() {
+			return fmt.Errorf("connection refused")
+		}
+		return nil
+	}), health.CheckConfig{})
+
+	report := registry.Readiness(context.Background())
+	assert.Equal(t, report.Status, health.StatusUp)
+	assert.Equal(t, len(report.Checks), 2)
+	assert.Assert(t, !report.Checks["db"].Cached)
+
+	cacheDown.Store(true)
+	report = registry.Readiness(context.Background())
+	assert.Equal(t, report.Status, health.StatusDegraded)
+	assert.Equal(t, report.Checks["cache"].Status, health.StatusDown)
+	assert.Equal(t, report.Checks["cache"].Error, "connection refused")
+	assert.Assert(t, report.Checks["db"].Cached)
+	assert.Equal(t, calls.Load(), int32(1))
+
+	report = registry.Liveness(context.Background())
+	assert.Equal(t, report.Status, health.StatusUp)
+	assert.Equal(t, len(report.Checks), 1)
+
+	registry.Register("queue", health.CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(time.Second)
+		return nil
+	}), health.CheckConfig{Critical: true, Timeout: time.Millisecond * 20})
+	st := time.Now()
+	report = registry.Readiness(context.Background())
+	assert.Assert(t, time.Since(st) < time.Millisecond*500)
+	assert.Equal(t, report.Status, health.StatusDown)
+	assert.Equal(t, report.Checks["queue"].Error, "health check timed out after 20ms")
+	assert.Assert(t, report.Checks["queue"].Critical)
+}
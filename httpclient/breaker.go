@@ -0,0 +1,90 @@
+package httpclient
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBreakerFailureThreshold = 5
+	DefaultBreakerOpenTimeout      = time.Second * 30
+)
+
+var ErrCircuitOpen = fmt.Errorf("circuit breaker open")
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+type BreakerConfig struct {
+	FailureThreshold int
+	OpenTimeout      time.Duration
+	Disabled         bool
+}
+
+type breaker struct {
+	config    BreakerConfig
+	lock      sync.Mutex
+	state     BreakerState
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func newBreaker(config BreakerConfig) *breaker {
+	return &breaker{config: config}
+}
+
+func (b *breaker) allow() bool {
+	if b.config.Disabled {
+		return true
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if time.Now().Before(b.openUntil) {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return true
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+	}
+	return true
+}
+
+func (b *breaker) record(success bool) {
+	if b.config.Disabled {
+		return
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if success {
+		b.state = BreakerClosed
+		b.failures = 0
+		b.probing = false
+		return
+	}
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.config.FailureThreshold {
+		b.state = BreakerOpen
+		b.openUntil = time.Now().Add(b.config.OpenTimeout)
+		b.probing = false
+	}
+}
+
+func (b *breaker) getState() BreakerState {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.state
+}
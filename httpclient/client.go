@@ -0,0 +1,281 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	e "errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sabariramc/goserverbase/errors"
+	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/trace"
+)
+
+const (
+	DefaultTimeout    = time.Second * 30
+	DefaultMaxRetries = 3
+	DefaultMinBackoff = time.Millisecond * 100
+	DefaultMaxBackoff = time.Second * 5
+
+	HttpHeaderIdempotencyKey = "Idempotency-Key"
+	HttpHeaderRetryAfter     = "Retry-After"
+	HttpHeaderContentType    = "Content-Type"
+	HttpContentTypeJSON      = "application/json"
+)
+
+var DefaultRetryStatus = []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+type Config struct {
+	Timeout    time.Duration
+	MaxRetries int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// MaxRetryAfter caps the wait requested by a Retry-After header, defaults to MaxBackoff; a longer wait is not retried
+	MaxRetryAfter time.Duration
+	RetryStatus   []int
+	Breaker       BreakerConfig
+}
+
+type Client struct {
+	client      *http.Client
+	log         *log.Logger
+	config      Config
+	retryStatus map[int]bool
+	breakers    sync.Map
+}
+
+func New(logger *log.Logger, config Config) *Client {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+	return NewWithClient(logger, &http.Client{Timeout: config.Timeout}, config)
+}
+
+func NewWithClient(logger *log.Logger, client *http.Client, config Config) *Client {
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	} else if config.MaxRetries == 0 {
+		config.MaxRetries = DefaultMaxRetries
+	}
+	if config.MinBackoff <= 0 {
+		config.MinBackoff = DefaultMinBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = DefaultMaxBackoff
+	}
+	if config.MaxRetryAfter <= 0 {
+		config.MaxRetryAfter = config.MaxBackoff
+	}
+	if config.RetryStatus == nil {
+		config.RetryStatus = DefaultRetryStatus
+	}
+	if config.Breaker.FailureThreshold <= 0 {
+		config.Breaker.FailureThreshold = DefaultBreakerFailureThreshold
+	}
+	if config.Breaker.OpenTimeout <= 0 {
+		config.Breaker.OpenTimeout = DefaultBreakerOpenTimeout
+	}
+	c := &Client{client: client, log: logger.NewResourceLogger("httpclient"), config: config, retryStatus: make(map[int]bool, len(config.RetryStatus))}
+	for _, status := range config.RetryStatus {
+		c.retryStatus[status] = true
+	}
+	return c
+}
+
+func (c *Client) Do(req *http.Request) (res *http.Response, err error) {
+	ctx := req.Context()
+	ctx, span := trace.Start(ctx, "HTTP "+req.Method, trace.SpanKindClient, trace.String("http.request.method", req.Method), trace.String("server.address", req.URL.Host), trace.String("url.full", req.URL.String()))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+	req = req.WithContext(ctx)
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("Client.Do: %w", err)
+		}
+	}
+	if req.Header.Get("x-correlation-id") == "" {
+		log.SetCorrelationHeader(ctx, req)
+		log.SetCustomerIdentifierHeader(ctx, req)
+	}
+	trace.Inject(ctx, trace.HeaderCarrier(req.Header))
+	c.log.Info(ctx, "Request", map[string]any{"method": req.Method, "url": req.URL.String(), "headers": c.log.MaskHeaders(req.Header)})
+	c.log.Debug(ctx, "Request-Body", parseBody(body))
+	cb := c.getBreaker(req.URL.Host)
+	retryable := idempotentMethods[req.Method] || req.Header.Get(HttpHeaderIdempotencyKey) != ""
+	for attempt := 0; ; attempt++ {
+		if !cb.allow() {
+			c.log.Error(ctx, "Circuit open for host: "+req.URL.Host, nil)
+			return nil, fmt.Errorf("Client.Do: %v: %w", req.URL.Host, ErrCircuitOpen)
+		}
+		attemptReq := req.Clone(ctx)
+		if body != nil {
+			attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+			attemptReq.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+		}
+		st := time.Now()
+		res, err = c.client.Do(attemptReq)
+		latency := time.Since(st).Milliseconds()
+		if err != nil {
+			cb.record(false)
+			c.log.Error(ctx, "Request failed", map[string]any{"attempt": attempt + 1, "latencyMs": latency, "error": err.Error()})
+			if !retryable || attempt >= c.config.MaxRetries || ctx.Err() != nil {
+				return nil, fmt.Errorf("Client.Do: %w", err)
+			}
+			if err = sleep(ctx, c.backoff(attempt)); err != nil {
+				return nil, fmt.Errorf("Client.Do: %w", err)
+			}
+			continue
+		}
+		cb.record(res.StatusCode < http.StatusInternalServerError)
+		resBody, readErr := io.ReadAll(res.Body)
+		res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(resBody))
+		logFields := map[string]any{"attempt": attempt + 1, "latencyMs": latency, "statusCode": res.StatusCode, "headers": c.log.MaskHeaders(res.Header)}
+		if res.StatusCode < http.StatusInternalServerError {
+			c.log.Info(ctx, "Response", logFields)
+			c.log.Debug(ctx, "Response-Body", parseBody(resBody))
+		} else {
+			c.log.Error(ctx, "Response", logFields)
+			c.log.Error(ctx, "Response-Body", parseBody(resBody))
+		}
+		if readErr != nil {
+			return res, fmt.Errorf("Client.Do: %w", readErr)
+		}
+		if !retryable || !c.retryStatus[res.StatusCode] || attempt >= c.config.MaxRetries {
+			return res, nil
+		}
+		wait, ok := retryAfter(res.Header.Get(HttpHeaderRetryAfter))
+		if !ok {
+			wait = c.backoff(attempt)
+		} else if wait > c.config.MaxRetryAfter {
+			c.log.Notice(ctx, "Retry-After exceeds the maximum wait, not retrying", map[string]any{"retryAfter": wait.String(), "maxRetryAfter": c.config.MaxRetryAfter.String()})
+			return res, nil
+		}
+		if err = sleep(ctx, wait); err != nil {
+			return res, fmt.Errorf("Client.Do: %w", err)
+		}
+	}
+}
+
+func (c *Client) Call(ctx context.Context, method, url string, reqBody, resBody any) (*http.Response, error) {
+	var body io.Reader
+	if reqBody != nil {
+		blob, err := json.Marshal(reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("Client.Call: %w", err)
+		}
+		body = bytes.NewReader(blob)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("Client.Call: %w", err)
+	}
+	if reqBody != nil {
+		req.Header.Set(HttpHeaderContentType, HttpContentTypeJSON)
+	}
+	res, err := c.Do(req)
+	if err != nil {
+		return res, fmt.Errorf("Client.Call: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode > 299 {
+		return res, fmt.Errorf("Client.Call: %w", NewHTTPError(res))
+	}
+	if resBody != nil && res.StatusCode != http.StatusNoContent {
+		if err = json.NewDecoder(res.Body).Decode(resBody); err != nil && !e.Is(err, io.EOF) {
+			return res, fmt.Errorf("Client.Call: %w", err)
+		}
+	}
+	return res, nil
+}
+
+func (c *Client) GetBreakerState(host string) BreakerState {
+	return c.getBreaker(host).getState()
+}
+
+func (c *Client) getBreaker(host string) *breaker {
+	if cb, ok := c.breakers.Load(host); ok {
+		return cb.(*breaker)
+	}
+	cb, _ := c.breakers.LoadOrStore(host, newBreaker(c.config.Breaker))
+	return cb.(*breaker)
+}
+
+func (c *Client) backoff(attempt int) time.Duration {
+	wait := c.config.MinBackoff << attempt
+	if wait <= 0 || wait > c.config.MaxBackoff {
+		wait = c.config.MaxBackoff
+	}
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+func NewHTTPError(res *http.Response) *errors.HTTPError {
+	blob, _ := io.ReadAll(res.Body)
+	res.Body = io.NopCloser(bytes.NewReader(blob))
+	var body errors.CustomError
+	if err := json.Unmarshal(blob, &body); err == nil && (body.ErrorCode != "" || body.ErrorMessage != "") {
+		return &errors.HTTPError{CustomError: body, ErrorStatusCode: res.StatusCode}
+	}
+	return errors.NewHTTPClientError(res.StatusCode, "", http.StatusText(res.StatusCode), nil, parseBody(blob))
+}
+
+func retryAfter(val string) (time.Duration, bool) {
+	if val == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(val); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(val); err == nil {
+		wait := time.Until(at)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func sleep(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func parseBody(blob []byte) any {
+	if len(blob) == 0 {
+		return nil
+	}
+	var data any
+	if err := json.Unmarshal(blob, &data); err != nil {
+		return string(blob)
+	}
+	return data
+}
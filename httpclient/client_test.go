@@ -0,0 +1,141 @@
+package httpclient_test
+
+import (
+	"context"
+	e "errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sabariramc/goserverbase/errors"
+	"github.com/sabariramc/goserverbase/httpclient"
+	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/log/logtest"
+	"gotest.tools/assert"
+)
+
+func testContext() context.Context {
+	ctx := context.WithValue(context.Background(), log.ContextKeyCorrelation, &log.CorrelationParam{CorrelationId: "corr-1"})
+	return context.WithValue(ctx, log.ContextKeyCustomerIdentifier, &log.CustomerIdentifier{CustomerId: "cust-1"})
+}
+
+func TestClientRetry(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Header.Get("x-correlation-id"), "corr-1")
+		assert.Equal(t, r.Header.Get("x-customer-id"), "cust-1")
+		if calls.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"name":"acme","token":"secret-token"}`))
+	}))
+	defer srv.Close()
+	logger, capture := logtest.NewTestLogger(t)
+	client := httpclient.New(logger, httpclient.Config{MinBackoff: time.Millisecond})
+	var res map[string]string
+	_, err := client.Call(testContext(), http.MethodGet, srv.URL, nil, &res)
+	assert.NilError(t, err)
+	assert.Equal(t, calls.Load(), int32(3))
+	assert.Equal(t, res["name"], "acme")
+	entry := capture.AssertLogged(t, log.DEBUG, "^Response-Body$")
+	assert.Assert(t, strings.Contains(string(entry.FullMessageJSON), log.RedactedValue), string(entry.FullMessageJSON))
+
+	calls.Store(0)
+	_, err = client.Call(testContext(), http.MethodPost, srv.URL, map[string]any{"name": "acme"}, nil)
+	var httpErr *errors.HTTPError
+	assert.Assert(t, e.As(err, &httpErr), err)
+	assert.Equal(t, httpErr.ErrorStatusCode, http.StatusServiceUnavailable)
+	assert.Equal(t, calls.Load(), int32(1))
+}
+
+func TestClientDecodeHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write(errors.NewHTTPClientError(http.StatusConflict, "TENANT_EXISTS", "Tenant already exists", nil, map[string]any{"tenantId": "t-1"}).GetErrorResponse())
+	}))
+	defer srv.Close()
+	logger, _ := logtest.NewTestLogger(t)
+	client := httpclient.New(logger, httpclient.Config{})
+	_, err := client.Call(testContext(), http.MethodPut, srv.URL, map[string]any{"id": "t-1"}, nil)
+	var httpErr *errors.HTTPError
+	assert.Assert(t, e.As(err, &httpErr), err)
+	assert.Equal(t, httpErr.ErrorStatusCode, http.StatusConflict)
+	assert.Equal(t, httpErr.ErrorCode, "TENANT_EXISTS")
+	assert.Equal(t, httpErr.ErrorMessage, "Tenant already exists")
+	assert.DeepEqual(t, httpErr.ErrorDescription, map[string]any{"tenantId": "t-1"})
+}
+
+func TestClientCircuitBreaker(t *testing.T) {
+	var calls atomic.Int32
+	var healthy atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	logger, _ := logtest.NewTestLogger(t)
+	client := httpclient.New(logger, httpclient.Config{MaxRetries: -1, Breaker: httpclient.BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Millisecond * 50}})
+	host := strings.TrimPrefix(srv.URL, "http://")
+	for i := 0; i < 2; i++ {
+		_, err := client.Call(testContext(), http.MethodGet, srv.URL, nil, nil)
+		assert.Assert(t, err != nil)
+	}
+	assert.Equal(t, client.GetBreakerState(host), httpclient.BreakerOpen)
+	_, err := client.Call(testContext(), http.MethodGet, srv.URL, nil, nil)
+	assert.Assert(t, e.Is(err, httpclient.ErrCircuitOpen), err)
+	assert.Equal(t, calls.Load(), int32(2))
+
+	time.Sleep(time.Millisecond * 60)
+	healthy.Store(true)
+	_, err = client.Call(testContext(), http.MethodGet, srv.URL, nil, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, client.GetBreakerState(host), httpclient.BreakerClosed)
+}
+
+func TestClientRetryAfterCap(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+	logger, _ := logtest.NewTestLogger(t)
+	client := httpclient.New(logger, httpclient.Config{MaxBackoff: time.Second})
+	st := time.Now()
+	res, err := client.Call(testContext(), http.MethodGet, srv.URL, nil, nil)
+	assert.Assert(t, err != nil)
+	assert.Equal(t, res.StatusCode, http.StatusTooManyRequests)
+	assert.Equal(t, calls.Load(), int32(1))
+	assert.Assert(t, time.Since(st) < time.Second)
+}
+
+func TestClientMasksSensitiveHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "session-secret"})
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	logger, capture := logtest.NewTestLogger(t)
+	client := httpclient.New(logger, httpclient.Config{})
+	req, _ := http.NewRequestWithContext(testContext(), http.MethodGet, srv.URL, nil)
+	req.Header.Set("Cookie", "session=cookie-secret")
+	req.Header.Set("Proxy-Authorization", "Basic proxy-secret")
+	_, err := client.Do(req)
+	assert.NilError(t, err)
+	assert.Equal(t, req.Header.Get("Cookie"), "session=cookie-secret")
+	for _, msg := range []string{"^Request$", "^Response$"} {
+		entry := capture.AssertLogged(t, log.INFO, msg)
+		logged := string(entry.FullMessageJSON)
+		assert.Assert(t, !strings.Contains(logged, "secret"), logged)
+	}
+}
@@ -0,0 +1,95 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var ErrNotOwner = fmt.Errorf("idempotency record is held by another request")
+
+// Record is held as an in progress lease by Begin until Complete stores the response, ExpireAt covers the lease or the response respectively
+type Record struct {
+	Key        string      `bson:"_id"`
+	Owner      string      `bson:"owner"`
+	BodyHash   string      `bson:"bodyHash"`
+	InProgress bool        `bson:"inProgress"`
+	StatusCode int         `bson:"statusCode"`
+	Header     http.Header `bson:"header"`
+	Body       []byte      `bson:"body"`
+	ExpireAt   time.Time   `bson:"expireAt"`
+}
+
+// Store implementations must let Begin take over records whose ExpireAt has passed.
+// Renew, Complete and Release only apply to the in progress record of record.Owner and return ErrNotOwner once another request has taken over
+type Store interface {
+	Begin(ctx context.Context, record *Record) (existing *Record, err error)
+	Renew(ctx context.Context, record *Record) error
+	Complete(ctx context.Context, record *Record) error
+	Release(ctx context.Context, record *Record) error
+}
+
+type MemoryStore struct {
+	lock    sync.Mutex
+	records map[string]*Record
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string]*Record)}
+}
+
+func (m *MemoryStore) Begin(ctx context.Context, record *Record) (*Record, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if existing, ok := m.records[record.Key]; ok && time.Now().Before(existing.ExpireAt) {
+		copied := *existing
+		return &copied, nil
+	}
+	copied := *record
+	copied.InProgress = true
+	m.records[record.Key] = &copied
+	return nil, nil
+}
+
+func (m *MemoryStore) Renew(ctx context.Context, record *Record) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	existing, ok := m.owned(record)
+	if !ok {
+		return ErrNotOwner
+	}
+	existing.ExpireAt = record.ExpireAt
+	return nil
+}
+
+func (m *MemoryStore) Complete(ctx context.Context, record *Record) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.owned(record); !ok {
+		return ErrNotOwner
+	}
+	copied := *record
+	copied.InProgress = false
+	m.records[record.Key] = &copied
+	return nil
+}
+
+func (m *MemoryStore) Release(ctx context.Context, record *Record) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.owned(record); !ok {
+		return ErrNotOwner
+	}
+	delete(m.records, record.Key)
+	return nil
+}
+
+func (m *MemoryStore) owned(record *Record) (*Record, bool) {
+	existing, ok := m.records[record.Key]
+	if !ok || !existing.InProgress || existing.Owner != record.Owner {
+		return nil, false
+	}
+	return existing, true
+}
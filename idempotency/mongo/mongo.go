@@ -0,0 +1,94 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sabariramc/goserverbase/idempotency"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Store struct {
+	collection *mongo.Collection
+}
+
+func New(ctx context.Context, collection *mongo.Collection) (*Store, error) {
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expireAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("mongo.New: %w", err)
+	}
+	return &Store{collection: collection}, nil
+}
+
+func (s *Store) Begin(ctx context.Context, record *idempotency.Record) (*idempotency.Record, error) {
+	doc := *record
+	doc.InProgress = true
+	_, err := s.collection.InsertOne(ctx, &doc)
+	if err == nil {
+		return nil, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, fmt.Errorf("Store.Begin: %w", err)
+	}
+	var existing idempotency.Record
+	err = s.collection.FindOne(ctx, bson.M{"_id": record.Key}).Decode(&existing)
+	if err == mongo.ErrNoDocuments {
+		return s.Begin(ctx, record)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Store.Begin: %w", err)
+	}
+	if time.Now().After(existing.ExpireAt) {
+		_, err = s.collection.DeleteOne(ctx, bson.M{"_id": record.Key, "expireAt": existing.ExpireAt})
+		if err != nil {
+			return nil, fmt.Errorf("Store.Begin: %w", err)
+		}
+		return s.Begin(ctx, record)
+	}
+	return &existing, nil
+}
+
+func (s *Store) Renew(ctx context.Context, record *idempotency.Record) error {
+	res, err := s.collection.UpdateOne(ctx, ownerFilter(record), bson.M{"$set": bson.M{"expireAt": record.ExpireAt}})
+	if err != nil {
+		return fmt.Errorf("Store.Renew: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("Store.Renew: %w", idempotency.ErrNotOwner)
+	}
+	return nil
+}
+
+func (s *Store) Complete(ctx context.Context, record *idempotency.Record) error {
+	doc := *record
+	doc.InProgress = false
+	res, err := s.collection.ReplaceOne(ctx, ownerFilter(record), &doc)
+	if err != nil {
+		return fmt.Errorf("Store.Complete: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("Store.Complete: %w", idempotency.ErrNotOwner)
+	}
+	return nil
+}
+
+func (s *Store) Release(ctx context.Context, record *idempotency.Record) error {
+	res, err := s.collection.DeleteOne(ctx, ownerFilter(record))
+	if err != nil {
+		return fmt.Errorf("Store.Release: %w", err)
+	}
+	if res.DeletedCount == 0 {
+		return fmt.Errorf("Store.Release: %w", idempotency.ErrNotOwner)
+	}
+	return nil
+}
+
+func ownerFilter(record *idempotency.Record) bson.M {
+	return bson.M{"_id": record.Key, "owner": record.Owner, "inProgress": true}
+}
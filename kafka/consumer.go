@@ -9,6 +9,7 @@ import (
 
 	"github.com/confluentinc/confluent-kafka-go/kafka"
 	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/trace"
 	"github.com/sabariramc/goserverbase/utils"
 )
 
@@ -20,6 +21,17 @@ type Consumer struct {
 	ready  bool
 }
 
+// Event is a message from PollEvents with the context extracted from its headers, call Done after processing to end the receive span
+type Event struct {
+	*kafka.Message
+	Ctx  context.Context
+	span *trace.Span
+}
+
+func (e *Event) Done() {
+	e.span.End()
+}
+
 func NewConsumer(ctx context.Context, log *log.Logger, config *KafkaConsumerConfig, topic string) (*Consumer, error) {
 	parsedConfig := &kafka.ConfigMap{}
 	utils.StrictJsonTransformer(config, parsedConfig)
@@ -31,7 +43,7 @@ func NewConsumer(ctx context.Context, log *log.Logger, config *KafkaConsumerConf
 	}
 	k := &Consumer{
 		config:   config,
-		log:      log,
+		log:      log.NewResourceLogger("kafka"),
 		Consumer: c,
 		topic:    topic,
 	}
@@ -48,8 +60,24 @@ func (k *Consumer) logReBalance(consumer *kafka.Consumer, e kafka.Event) error {
 	return nil
 }
 
+// Poll ends the receive span before handing over the message, use GetMessageContext for the propagated context
 func (k *Consumer) Poll(ctx context.Context, timeout int, outChannel chan *kafka.Message) error {
 	defer close(outChannel)
+	return k.poll(ctx, timeout, func(msgCtx context.Context, span *trace.Span, e *kafka.Message) {
+		span.End()
+		outChannel <- e
+	})
+}
+
+// PollEvents is Poll with the message context attached, the receive span stays open until Event.Done is called
+func (k *Consumer) PollEvents(ctx context.Context, timeout int, outChannel chan *Event) error {
+	defer close(outChannel)
+	return k.poll(ctx, timeout, func(msgCtx context.Context, span *trace.Span, e *kafka.Message) {
+		outChannel <- &Event{Message: e, Ctx: msgCtx, span: span}
+	})
+}
+
+func (k *Consumer) poll(ctx context.Context, timeout int, emit func(msgCtx context.Context, span *trace.Span, e *kafka.Message)) error {
 	var err error
 	k.log.Info(ctx, "Polling started for topic : "+k.topic, nil)
 outer:
@@ -62,8 +90,10 @@ outer:
 			ev := k.Consumer.Poll(timeout)
 			switch e := ev.(type) {
 			case *kafka.Message:
-				outChannel <- e
-				k.log.Debug(ctx, "Polling result", e)
+				messagesConsumed.WithLabelValues(k.topic).Inc()
+				msgCtx, span := trace.Start(GetMessageContext(ctx, e), "receive "+k.topic, trace.SpanKindConsumer, trace.String("messaging.system", "kafka"), trace.String("messaging.source.name", k.topic))
+				k.log.Debug(msgCtx, "Polling result", e)
+				emit(msgCtx, span, e)
 			case kafka.PartitionEOF:
 				k.log.Info(ctx, "Reached EOF, Ending poll", e)
 				break outer
@@ -86,6 +116,7 @@ func (k *Consumer) ReadMessage(ctx context.Context, timeout time.Duration) (*kaf
 		k.log.Error(ctx, "Error reading message from topic: "+k.topic, err)
 		return nil, fmt.Errorf("KafkaConsumer.ReadMessage: %w", err)
 	}
+	messagesConsumed.WithLabelValues(k.topic).Inc()
 	return ev, err
 }
 
@@ -98,6 +129,31 @@ func (k *Consumer) Close(ctx context.Context) error {
 	return nil
 }
 
+func (k *Consumer) Name() string {
+	return "KafkaConsumer:" + k.topic
+}
+
+func (k *Consumer) Shutdown(ctx context.Context) error {
+	return k.Close(ctx)
+}
+
+func GetMessageContext(ctx context.Context, msg *kafka.Message) context.Context {
+	headers := make(map[string]string, len(msg.Headers))
+	for _, h := range msg.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+	ctx = trace.Extract(ctx, trace.MapCarrier(headers))
+	correlation := &log.CorrelationParam{}
+	if utils.LenientJsonTransformer(headers, correlation) == nil && correlation.CorrelationId != "" {
+		ctx = context.WithValue(ctx, log.ContextKeyCorrelation, correlation)
+	}
+	customer := &log.CustomerIdentifier{}
+	if utils.LenientJsonTransformer(headers, customer) == nil && *customer != (log.CustomerIdentifier{}) {
+		ctx = context.WithValue(ctx, log.ContextKeyCustomerIdentifier, customer)
+	}
+	return ctx
+}
+
 func LoadMessage(src *kafka.Message) (*utils.Message, error) {
 	msg := &utils.Message{}
 	r := bytes.NewReader(src.Value)
@@ -0,0 +1,48 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+)
+
+const DefaultMetadataTimeout = time.Second * 5
+
+type metadataClient interface {
+	GetMetadata(topic *string, allTopics bool, timeoutMs int) (*kafka.Metadata, error)
+}
+
+func (k *Producer) HealthCheck(ctx context.Context) error {
+	if err := checkTopicMetadata(ctx, k.Producer, k.topic); err != nil {
+		return fmt.Errorf("KafkaProducer.HealthCheck: %w", err)
+	}
+	return nil
+}
+
+func (k *Consumer) HealthCheck(ctx context.Context) error {
+	if err := checkTopicMetadata(ctx, k.Consumer, k.topic); err != nil {
+		return fmt.Errorf("KafkaConsumer.HealthCheck: %w", err)
+	}
+	return nil
+}
+
+func checkTopicMetadata(ctx context.Context, client metadataClient, topic string) error {
+	timeout := DefaultMetadataTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	metadata, err := client.GetMetadata(&topic, false, int(timeout.Milliseconds()))
+	if err != nil {
+		return err
+	}
+	topicMetadata, ok := metadata.Topics[topic]
+	if !ok {
+		return fmt.Errorf("topic %v not found in metadata", topic)
+	}
+	if topicMetadata.Error.Code() != kafka.ErrNoError {
+		return topicMetadata.Error
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package kafka
+
+import "github.com/sabariramc/goserverbase/metrics"
+
+const (
+	metricStatusSuccess = "success"
+	metricStatusFailure = "failure"
+)
+
+var messagesProduced = metrics.NewCounterVec("kafka_messages_produced_total", "Kafka messages produced", "topic", "status")
+var messagesConsumed = metrics.NewCounterVec("kafka_messages_consumed_total", "Kafka messages consumed", "topic")
+var deliveryLatency = metrics.NewHistogramVec("kafka_delivery_duration_seconds", "Kafka produce to delivery report latency in seconds", nil, "topic")
+
+func init() {
+	metrics.MustRegister(messagesProduced, messagesConsumed, deliveryLatency)
+}
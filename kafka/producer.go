@@ -11,6 +11,7 @@ import (
 
 	"github.com/confluentinc/confluent-kafka-go/kafka"
 	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/trace"
 	"github.com/sabariramc/goserverbase/utils"
 )
 
@@ -32,7 +33,7 @@ func NewProducer(ctx context.Context, log *log.Logger, config *KafkaProducerConf
 	}
 	k := &Producer{
 		config:   config,
-		log:      log,
+		log:      log.NewResourceLogger("kafka"),
 		Producer: p,
 		topic:    topic,
 	}
@@ -40,6 +41,11 @@ func NewProducer(ctx context.Context, log *log.Logger, config *KafkaProducerConf
 }
 
 func (k *Producer) Produce(ctx context.Context, key string, message *utils.Message) (m *kafka.Message, err error) {
+	ctx, span := trace.Start(ctx, "send "+k.topic, trace.SpanKindProducer, trace.String("messaging.system", "kafka"), trace.String("messaging.destination.name", k.topic))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
 	var buf bytes.Buffer
 	deliveryChannel := make(chan kafka.Event)
 	defer close(deliveryChannel)
@@ -54,6 +60,7 @@ func (k *Producer) Produce(ctx context.Context, key string, message *utils.Messa
 	customerIdentity := log.GetCustomerIdentifier(ctx)
 	utils.StrictJsonTransformer(correlationParam, &headers)
 	utils.StrictJsonTransformer(customerIdentity, &headers)
+	trace.Inject(ctx, trace.MapCarrier(headers))
 	messageHeader := make([]kafka.Header, 0)
 	for i, v := range headers {
 		messageHeader = append(messageHeader, kafka.Header{
@@ -62,24 +69,61 @@ func (k *Producer) Produce(ctx context.Context, key string, message *utils.Messa
 		})
 	}
 	k.log.Debug(ctx, "Message payload", map[string]any{"body": message, "key": key, "headers": messageHeader})
-	k.Producer.Produce(&kafka.Message{
+	st := time.Now()
+	err = k.Producer.Produce(&kafka.Message{
 		TopicPartition: kafka.TopicPartition{Topic: &k.topic, Partition: kafka.PartitionAny},
 		Key:            []byte(key),
 		Value:          buf.Bytes(),
 		Headers:        messageHeader,
 		Timestamp:      time.Now(),
 	}, deliveryChannel)
+	if err != nil {
+		messagesProduced.WithLabelValues(k.topic, metricStatusFailure).Inc()
+		k.log.Error(ctx, "Enqueue failed for topic: "+k.topic, err)
+		return nil, fmt.Errorf("KafkaProducer.Send.ProduceMessage: %w", err)
+	}
 	e := <-deliveryChannel
+	deliveryLatency.WithLabelValues(k.topic).ObserveDuration(st)
 	m = e.(*kafka.Message)
 	err = m.TopicPartition.Error
 	if err != nil {
+		messagesProduced.WithLabelValues(k.topic, metricStatusFailure).Inc()
 		k.log.Error(ctx, "Send failed for topic: "+k.topic, err)
 		return nil, fmt.Errorf("KafkaProducer.Send.ProduceMessage: %w", err)
 	}
+	messagesProduced.WithLabelValues(k.topic, metricStatusSuccess).Inc()
 	k.log.Info(ctx, "Send success for topic: "+k.topic, m)
 	return m, nil
 }
 
+func (k *Producer) FlushWithContext(ctx context.Context) error {
+	for {
+		pending := k.Producer.Flush(100)
+		if pending == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			k.log.Error(ctx, fmt.Sprintf("Flush timed out with %v pending message for topic: %v", pending, k.topic), ctx.Err())
+			return fmt.Errorf("KafkaProducer.FlushWithContext: %w", ctx.Err())
+		default:
+		}
+	}
+}
+
+func (k *Producer) Name() string {
+	return "KafkaProducer:" + k.topic
+}
+
+func (k *Producer) Shutdown(ctx context.Context) error {
+	err := k.FlushWithContext(ctx)
+	k.Producer.Close()
+	if err != nil {
+		return fmt.Errorf("KafkaProducer.Shutdown: %w", err)
+	}
+	return nil
+}
+
 type HTTPProducer struct {
 	baseUrl    string
 	log        *log.Logger
@@ -88,10 +132,15 @@ type HTTPProducer struct {
 }
 
 func NewHTTPProducer(ctx context.Context, log *log.Logger, baseURL, topicName string, timeout time.Duration) *HTTPProducer {
-	return &HTTPProducer{baseUrl: baseURL, topicName: topicName, log: log, httpClient: &http.Client{Timeout: timeout}}
+	return &HTTPProducer{baseUrl: baseURL, topicName: topicName, log: log.NewResourceLogger("kafka"), httpClient: &http.Client{Timeout: timeout}}
 }
 
-func (k HTTPProducer) Produce(ctx context.Context, key string, message *utils.Message) (*kafka.Message, error) {
+func (k HTTPProducer) Produce(ctx context.Context, key string, message *utils.Message) (_ *kafka.Message, err error) {
+	ctx, span := trace.Start(ctx, "send "+k.topicName, trace.SpanKindProducer, trace.String("messaging.system", "kafka"), trace.String("messaging.destination.name", k.topicName))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
 	url := k.baseUrl + "/" + k.topicName
 	data := map[string]any{
 		"records": []map[string]any{{
@@ -102,7 +151,7 @@ func (k HTTPProducer) Produce(ctx context.Context, key string, message *utils.Me
 	}
 
 	var reqBodyBlob bytes.Buffer
-	err := json.NewEncoder(&reqBodyBlob).Encode(&data)
+	err = json.NewEncoder(&reqBodyBlob).Encode(&data)
 	if err != nil {
 		k.log.Error(ctx, "KafkaHTTPProducer.Send.PayloadEncoding", err)
 		return nil, fmt.Errorf("KafkaHTTPProducer.Send.PayloadEncoding: %w", err)
@@ -113,12 +162,16 @@ func (k HTTPProducer) Produce(ctx context.Context, key string, message *utils.Me
 		return nil, fmt.Errorf("KafkaHTTPProducer.Send.RequestCreation: %w", err)
 	}
 	log.SetCorrelationHeader(ctx, req)
+	trace.Inject(ctx, trace.HeaderCarrier(req.Header))
 	req.Header.Add("Content-Type", "application/vnd.kafka.json.v2+json")
 	k.log.Debug(ctx, "Request payload", data)
 	k.log.Debug(ctx, "Request header", req.Header)
 	k.log.Debug(ctx, "Request url", req.URL)
+	st := time.Now()
 	res, err := k.httpClient.Do(req)
+	deliveryLatency.WithLabelValues(k.topicName).ObserveDuration(st)
 	if err != nil {
+		messagesProduced.WithLabelValues(k.topicName, metricStatusFailure).Inc()
 		k.log.Error(ctx, "Error in sending kafka message", err)
 		return nil, fmt.Errorf("KafkaHTTPProducer.Send.HTTPCall: %w", err)
 	}
@@ -132,9 +185,11 @@ func (k HTTPProducer) Produce(ctx context.Context, key string, message *utils.Me
 		resBody = string(blobBody)
 	}
 	if res.StatusCode > 299 {
+		messagesProduced.WithLabelValues(k.topicName, metricStatusFailure).Inc()
 		err = fmt.Errorf("KafkaHTTPProducer.Send.HTTPCall.statusCode: %v", res.StatusCode)
 		k.log.Error(ctx, fmt.Sprintf("KAFKA HTTP response -%v", res.StatusCode), resBody)
 	} else {
+		messagesProduced.WithLabelValues(k.topicName, metricStatusSuccess).Inc()
 		k.log.Debug(ctx, fmt.Sprintf("KAFKA HTTP response -%v", res.StatusCode), resBody)
 	}
 	return nil, err
@@ -1,7 +1,49 @@
 package log
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
+// AuditLogWriter receives the payload given to Logger.Audit as is, use NewAuditRecord to add the actor, correlation and customer from ctx
 type AuditLogWriter interface {
 	WriteMessage(context.Context, interface{}) error
 }
+
+type AuditRecord struct {
+	Timestamp   time.Time           `json:"timestamp" bson:"timestamp"`
+	Service     string              `json:"service" bson:"service"`
+	Actor       string              `json:"actor" bson:"actor"`
+	Correlation *CorrelationParam   `json:"correlation" bson:"correlation"`
+	Customer    *CustomerIdentifier `json:"customer" bson:"customer"`
+	Event       interface{}         `json:"event" bson:"event"`
+}
+
+func NewAuditRecord(ctx context.Context, service string, event interface{}) *AuditRecord {
+	if record, ok := event.(*AuditRecord); ok {
+		return record
+	}
+	return &AuditRecord{
+		Timestamp:   time.Now().UTC(),
+		Service:     service,
+		Actor:       GetActor(ctx),
+		Correlation: GetCorrelationParam(ctx),
+		Customer:    GetCustomerIdentifier(ctx),
+		Event:       event,
+	}
+}
+
+func SetActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, ContextKeyActor, actor)
+}
+
+func GetActor(ctx context.Context) string {
+	if actor, ok := ctx.Value(ContextKeyActor).(string); ok && actor != "" {
+		return actor
+	}
+	customer := GetCustomerIdentifier(ctx)
+	if customer.AppUserId != "" {
+		return customer.AppUserId
+	}
+	return customer.CustomerId
+}
@@ -0,0 +1,176 @@
+package file
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sabariramc/goserverbase/log"
+)
+
+var GenesisHash = strings.Repeat("0", sha256.Size*2)
+
+var ErrChainBroken = fmt.Errorf("audit hash chain broken")
+
+var ErrPartialRecord = fmt.Errorf("%w: unterminated trailing record", ErrChainBroken)
+
+const partialTimeFormat = "20060102T150405.000000000"
+
+type chainEntry struct {
+	Seq      int64           `json:"seq"`
+	PrevHash string          `json:"prevHash"`
+	Record   json.RawMessage `json:"record"`
+}
+
+type chainLine struct {
+	chainEntry
+	Hash string `json:"hash"`
+}
+
+type AuditWriter struct {
+	file        *os.File
+	serviceName string
+	seq         int64
+	lastHash    string
+	lock        sync.Mutex
+}
+
+// New resumes the chain in path. An unterminated trailing record, from an interrupted write or tampering, is moved to a
+// path.partial.<time> side file and reported on stderr so the chain can continue without losing it
+func New(path, serviceName string) (*AuditWriter, error) {
+	seq, lastHash, size, err := readChain(path)
+	if errors.Is(err, ErrPartialRecord) {
+		err = quarantineTail(path, size)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("file.New: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("file.New: %w", err)
+	}
+	return &AuditWriter{file: f, serviceName: serviceName, seq: seq, lastHash: lastHash}, nil
+}
+
+func (a *AuditWriter) WriteMessage(ctx context.Context, msg interface{}) error {
+	record, err := json.Marshal(log.NewAuditRecord(ctx, a.serviceName, msg))
+	if err != nil {
+		return fmt.Errorf("AuditWriter.WriteMessage: %w", err)
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	line := chainLine{chainEntry: chainEntry{Seq: a.seq + 1, PrevHash: a.lastHash, Record: record}}
+	line.Hash, err = hashEntry(line.chainEntry)
+	if err != nil {
+		return fmt.Errorf("AuditWriter.WriteMessage: %w", err)
+	}
+	blob, err := json.Marshal(line)
+	if err != nil {
+		return fmt.Errorf("AuditWriter.WriteMessage: %w", err)
+	}
+	if _, err = a.file.Write(append(blob, '\n')); err != nil {
+		return fmt.Errorf("AuditWriter.WriteMessage: %w", err)
+	}
+	a.seq = line.Seq
+	a.lastHash = line.Hash
+	return nil
+}
+
+func (a *AuditWriter) Close() error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.file.Close()
+}
+
+// Verify checks every line of the chain, an unterminated trailing record fails with ErrPartialRecord
+func Verify(path string) error {
+	if _, _, _, err := readChain(path); err != nil {
+		return fmt.Errorf("file.Verify: %w", err)
+	}
+	return nil
+}
+
+func hashEntry(entry chainEntry) (string, error) {
+	blob, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(blob)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// readChain validates the lines in path and returns the size of the valid prefix, which is kept alongside ErrPartialRecord
+func readChain(path string) (seq int64, lastHash string, size int64, err error) {
+	lastHash = GenesisHash
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return 0, lastHash, 0, nil
+	}
+	if err != nil {
+		return 0, "", 0, err
+	}
+	defer f.Close()
+	reader := bufio.NewReader(f)
+	for {
+		blob, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			if len(blob) > 0 {
+				return seq, lastHash, size, fmt.Errorf("%w: line %v", ErrPartialRecord, seq+1)
+			}
+			return seq, lastHash, size, nil
+		}
+		if err != nil {
+			return 0, "", 0, err
+		}
+		var line chainLine
+		if err := json.Unmarshal(blob, &line); err != nil {
+			return 0, "", 0, fmt.Errorf("%w: line %v: %v", ErrChainBroken, seq+1, err)
+		}
+		if line.Seq != seq+1 || line.PrevHash != lastHash {
+			return 0, "", 0, fmt.Errorf("%w: line %v: sequence or previous hash mismatch", ErrChainBroken, seq+1)
+		}
+		hash, err := hashEntry(line.chainEntry)
+		if err != nil || hash != line.Hash {
+			return 0, "", 0, fmt.Errorf("%w: line %v: hash mismatch", ErrChainBroken, seq+1)
+		}
+		seq, lastHash = line.Seq, line.Hash
+		size += int64(len(blob))
+	}
+}
+
+func quarantineTail(path string, size int64) error {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	side := path + ".partial." + time.Now().UTC().Format(partialTimeFormat)
+	f, err := os.OpenFile(side, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(blob[size:]); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Truncate(path, size); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "file.New: %v: unterminated trailing record of %v bytes moved from %v to %v\n", ErrChainBroken, len(blob)-int(size), path, side)
+	return nil
+}
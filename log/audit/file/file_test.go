@@ -0,0 +1,95 @@
+package file_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/log/audit/file"
+	"gotest.tools/assert"
+)
+
+func TestHashChain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	ctx := context.WithValue(context.Background(), log.ContextKeyCorrelation, &log.CorrelationParam{CorrelationId: "corr-1"})
+	ctx = context.WithValue(ctx, log.ContextKeyCustomerIdentifier, &log.CustomerIdentifier{CustomerId: "cust-1"})
+	writer, err := file.New(path, "test")
+	assert.NilError(t, err)
+	assert.NilError(t, writer.WriteMessage(ctx, map[string]any{"action": "create", "amount": 100}))
+	assert.NilError(t, writer.WriteMessage(log.SetActor(ctx, "admin"), map[string]any{"action": "approve"}))
+	assert.NilError(t, writer.Close())
+	writer, err = file.New(path, "test")
+	assert.NilError(t, err)
+	assert.NilError(t, writer.WriteMessage(ctx, map[string]any{"action": "delete"}))
+	assert.NilError(t, writer.Close())
+	assert.NilError(t, file.Verify(path))
+
+	blob, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(blob)), "\n")
+	assert.Equal(t, len(lines), 3)
+	assert.Assert(t, strings.Contains(lines[0], `"actor":"cust-1"`), lines[0])
+	assert.Assert(t, strings.Contains(lines[1], `"actor":"admin"`), lines[1])
+	assert.Assert(t, strings.Contains(lines[0], `"x-correlation-id":"corr-1"`), lines[0])
+
+	tampered := strings.Replace(string(blob), `"amount":100`, `"amount":1000`, 1)
+	assert.NilError(t, os.WriteFile(path, []byte(tampered), 0600))
+	err = file.Verify(path)
+	assert.Assert(t, errors.Is(err, file.ErrChainBroken), err)
+
+	lines = append(lines[:1], lines[2:]...)
+	assert.NilError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600))
+	err = file.Verify(path)
+	assert.Assert(t, errors.Is(err, file.ErrChainBroken), err)
+}
+
+func TestHashChainPartialTrailingLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	writer, err := file.New(path, "test")
+	assert.NilError(t, err)
+	assert.NilError(t, writer.WriteMessage(context.Background(), map[string]any{"action": "create"}))
+	assert.NilError(t, writer.Close())
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	assert.NilError(t, err)
+	_, err = f.WriteString(`{"seq":2,"prevHash":"`)
+	assert.NilError(t, err)
+	assert.NilError(t, f.Close())
+	err = file.Verify(path)
+	assert.Assert(t, errors.Is(err, file.ErrPartialRecord), err)
+
+	writer, err = file.New(path, "test")
+	assert.NilError(t, err)
+	assert.NilError(t, writer.WriteMessage(context.Background(), map[string]any{"action": "update"}))
+	assert.NilError(t, writer.Close())
+	assert.NilError(t, file.Verify(path))
+	blob, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(blob)), "\n")
+	assert.Equal(t, len(lines), 2)
+	assert.Assert(t, strings.HasPrefix(lines[1], `{"seq":2,`), lines[1])
+	sides, err := filepath.Glob(path + ".partial.*")
+	assert.NilError(t, err)
+	assert.Equal(t, len(sides), 1)
+	blob, err = os.ReadFile(sides[0])
+	assert.NilError(t, err)
+	assert.Equal(t, string(blob), `{"seq":2,"prevHash":"`)
+}
+
+func TestHashChainTamperedUnterminatedLastRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	writer, err := file.New(path, "test")
+	assert.NilError(t, err)
+	assert.NilError(t, writer.WriteMessage(context.Background(), map[string]any{"action": "create"}))
+	assert.NilError(t, writer.WriteMessage(context.Background(), map[string]any{"action": "approve", "amount": 100}))
+	assert.NilError(t, writer.Close())
+	blob, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	tampered := strings.TrimSuffix(strings.Replace(string(blob), `"amount":100`, `"amount":1000`, 1), "\n")
+	assert.NilError(t, os.WriteFile(path, []byte(tampered), 0600))
+	err = file.Verify(path)
+	assert.Assert(t, errors.Is(err, file.ErrChainBroken), err)
+}
@@ -0,0 +1,34 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	cKafka "github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/utils"
+)
+
+type Producer interface {
+	Produce(ctx context.Context, key string, message *utils.Message) (*cKafka.Message, error)
+}
+
+type AuditWriter struct {
+	producer    Producer
+	serviceName string
+}
+
+func New(producer Producer, serviceName string) *AuditWriter {
+	return &AuditWriter{producer: producer, serviceName: serviceName}
+}
+
+func (a *AuditWriter) WriteMessage(ctx context.Context, msg interface{}) error {
+	record := log.NewAuditRecord(ctx, a.serviceName, msg)
+	message := utils.NewMessage("audit", a.serviceName)
+	message.AddPayload("audit", &utils.Payload{"entity": record})
+	_, err := a.producer.Produce(ctx, record.Correlation.CorrelationId, message)
+	if err != nil {
+		return fmt.Errorf("AuditWriter.WriteMessage: %w", err)
+	}
+	return nil
+}
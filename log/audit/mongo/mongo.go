@@ -0,0 +1,31 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sabariramc/goserverbase/log"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Inserter interface {
+	InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
+}
+
+type AuditWriter struct {
+	collection  Inserter
+	serviceName string
+}
+
+func New(collection Inserter, serviceName string) *AuditWriter {
+	return &AuditWriter{collection: collection, serviceName: serviceName}
+}
+
+func (a *AuditWriter) WriteMessage(ctx context.Context, msg interface{}) error {
+	_, err := a.collection.InsertOne(ctx, log.NewAuditRecord(ctx, a.serviceName, msg))
+	if err != nil {
+		return fmt.Errorf("AuditWriter.WriteMessage: %w", err)
+	}
+	return nil
+}
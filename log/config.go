@@ -7,4 +7,10 @@ type Config struct {
 	LogLevel          int
 	BufferSize        int
 	AuthHeaderKeyList []string
+	RedactKeys        []string
+	RedactPaths       []string
+	DisableRedaction  bool
+	RedactPatterns    bool
+	Sampling          *SamplingConfig
+	EnableCaller      bool
 }
@@ -5,4 +5,6 @@ type ContextVariable string
 const (
 	ContextKeyCorrelation        ContextVariable = "correlationParam"
 	ContextKeyCustomerIdentifier ContextVariable = "customerIdentity"
+	ContextKeyFields             ContextVariable = "logFields"
+	ContextKeyActor              ContextVariable = "auditActor"
 )
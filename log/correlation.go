@@ -66,3 +66,35 @@ func SetCorrelationHeader(ctx context.Context, req *http.Request) {
 		req.Header.Add(i, v)
 	}
 }
+
+func SetCustomerIdentifierHeader(ctx context.Context, req *http.Request) {
+	identity := GetCustomerIdentifier(ctx)
+	headers := make(map[string]string, 0)
+	utils.StrictJsonTransformer(identity, &headers)
+	for i, v := range headers {
+		if v != "" {
+			req.Header.Add(i, v)
+		}
+	}
+}
+
+var DefaultSensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// MaskHeaders returns a copy of h with DefaultSensitiveHeaders and the configured AuthHeaderKeyList masked
+func (l *Logger) MaskHeaders(h http.Header) http.Header {
+	masked := h.Clone()
+	mask := func(key string) {
+		if len(masked.Values(key)) != 0 {
+			masked.Set(key, RedactedValue)
+		}
+	}
+	for _, key := range DefaultSensitiveHeaders {
+		mask(key)
+	}
+	if l.config != nil {
+		for _, key := range l.config.AuthHeaderKeyList {
+			mask(key)
+		}
+	}
+	return masked
+}
@@ -0,0 +1,94 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+)
+
+const badKey = "!BADKEY"
+
+func (l *Logger) With(args ...any) *Logger {
+	if l == nil {
+		return nil
+	}
+	child := *l
+	child.fields = mergeFields(l.fields, argsToFields(args))
+	return &child
+}
+
+func WithFields(ctx context.Context, args ...any) context.Context {
+	return context.WithValue(ctx, ContextKeyFields, mergeFields(GetFields(ctx), argsToFields(args)))
+}
+
+func GetFields(ctx context.Context) map[string]any {
+	fields, _ := ctx.Value(ContextKeyFields).(map[string]any)
+	return fields
+}
+
+func argsToFields(args []any) map[string]any {
+	attrs := make([]slog.Attr, 0, len(args))
+	for len(args) > 0 {
+		switch key := args[0].(type) {
+		case slog.Attr:
+			attrs = append(attrs, key)
+			args = args[1:]
+		case string:
+			if len(args) == 1 {
+				attrs = append(attrs, slog.String(badKey, key))
+				args = args[1:]
+				continue
+			}
+			attrs = append(attrs, slog.Any(key, args[1]))
+			args = args[2:]
+		case map[string]any:
+			for k, v := range key {
+				attrs = append(attrs, slog.Any(k, v))
+			}
+			args = args[1:]
+		default:
+			attrs = append(attrs, slog.Any(badKey, key))
+			args = args[1:]
+		}
+	}
+	return AttrsToMap(attrs...)
+}
+
+func mergeFields(fieldList ...map[string]any) map[string]any {
+	size := 0
+	for _, fields := range fieldList {
+		size += len(fields)
+	}
+	if size == 0 {
+		return nil
+	}
+	res := make(map[string]any, size)
+	for _, fields := range fieldList {
+		for key, value := range fields {
+			res[key] = value
+		}
+	}
+	return res
+}
+
+func getCaller(skip int) string {
+	_, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+	return formatCaller(file, line)
+}
+
+func getCallerFromPC(pc uintptr) string {
+	if pc == 0 {
+		return ""
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return formatCaller(frame.File, frame.Line)
+}
+
+func formatCaller(file string, line int) string {
+	return fmt.Sprintf("%v/%v:%v", filepath.Base(filepath.Dir(file)), filepath.Base(file), line)
+}
@@ -0,0 +1,33 @@
+package log_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sabariramc/goserverbase/log"
+	"gotest.tools/assert"
+)
+
+func TestLoggerWithFields(t *testing.T) {
+	writer := &captureWriter{}
+	logger := log.NewLogger(context.TODO(), &log.Config{ServiceName: "test", LogLevel: int(log.INFO), DisableRedaction: true, EnableCaller: true}, "FieldTest", log.NewDefaultLogMux(writer), nil)
+	child := logger.With("orderId", "o-1", "attempt", 2)
+	ctx := log.WithFields(context.Background(), "workflow", "checkout")
+	ctx = log.WithFields(ctx, "step", "payment")
+	child.Info(ctx, "Order processed", nil)
+	logger.Info(context.Background(), "Parent", nil)
+	assert.Equal(t, len(writer.messages), 2)
+	assert.DeepEqual(t, writer.messages[0].Fields, map[string]any{"orderId": "o-1", "attempt": int64(2), "workflow": "checkout", "step": "payment"})
+	assert.Assert(t, strings.HasPrefix(writer.messages[0].Caller, "log/fields_test.go:"), writer.messages[0].Caller)
+	assert.Equal(t, len(writer.messages[1].Fields), 0)
+}
+
+func TestLoggerFieldsRedaction(t *testing.T) {
+	writer := &captureWriter{}
+	logger := log.NewLogger(context.TODO(), &log.Config{ServiceName: "test", LogLevel: int(log.INFO), RedactPatterns: true}, "FieldTest", log.NewDefaultLogMux(writer), nil)
+	logger.With("token", "abc", "email", "jane@example.com").Info(context.Background(), "Login", nil)
+	assert.Equal(t, writer.messages[0].Fields["token"], log.RedactedValue)
+	assert.Equal(t, writer.messages[0].Fields["email"], "j***@example.com")
+	assert.Equal(t, writer.messages[0].Caller, "")
+}
@@ -0,0 +1,112 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type ModuleLogLevel struct {
+	Module    string     `json:"module"`
+	Level     string     `json:"level"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type levelOverride struct {
+	level     LogLevelCode
+	expiresAt *time.Time
+	timer     *time.Timer
+}
+
+type levelRegistry struct {
+	lock      sync.Mutex
+	overrides atomic.Pointer[map[string]*levelOverride]
+}
+
+var moduleLevels = newLevelRegistry()
+
+func newLevelRegistry() *levelRegistry {
+	r := &levelRegistry{}
+	r.overrides.Store(&map[string]*levelOverride{})
+	return r
+}
+
+func ParseLogLevel(name string) (LogLevelCode, error) {
+	for code, level := range logLevelMap {
+		if strings.EqualFold(level.LogLevelName, name) {
+			return code, nil
+		}
+	}
+	return INFO, fmt.Errorf("log.ParseLogLevel: invalid log level %v", name)
+}
+
+func SetModuleLogLevel(module string, level LogLevelCode, ttl time.Duration) {
+	moduleLevels.set(module, level, ttl)
+}
+
+func ResetModuleLogLevel(module string) {
+	moduleLevels.reset(module, nil)
+}
+
+func GetModuleLogLevel(module string) (LogLevelCode, bool) {
+	override, ok := (*moduleLevels.overrides.Load())[module]
+	if !ok {
+		return INFO, false
+	}
+	return override.level, true
+}
+
+func GetModuleLogLevels() []ModuleLogLevel {
+	overrides := *moduleLevels.overrides.Load()
+	res := make([]ModuleLogLevel, 0, len(overrides))
+	for module, override := range overrides {
+		res = append(res, ModuleLogLevel{Module: module, Level: logLevelMap[override.level].LogLevelName, ExpiresAt: override.expiresAt})
+	}
+	return res
+}
+
+func (r *levelRegistry) set(module string, level LogLevelCode, ttl time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	override := &levelOverride{level: level}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		override.expiresAt = &expiresAt
+		override.timer = time.AfterFunc(ttl, func() {
+			r.reset(module, override)
+		})
+	}
+	r.update(func(overrides map[string]*levelOverride) {
+		if old, ok := overrides[module]; ok && old.timer != nil {
+			old.timer.Stop()
+		}
+		overrides[module] = override
+	})
+}
+
+func (r *levelRegistry) reset(module string, expected *levelOverride) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.update(func(overrides map[string]*levelOverride) {
+		old, ok := overrides[module]
+		if !ok || (expected != nil && old != expected) {
+			return
+		}
+		if old.timer != nil {
+			old.timer.Stop()
+		}
+		delete(overrides, module)
+	})
+}
+
+func (r *levelRegistry) update(fn func(map[string]*levelOverride)) {
+	current := *r.overrides.Load()
+	next := make(map[string]*levelOverride, len(current)+1)
+	for key, value := range current {
+		next[key] = value
+	}
+	fn(next)
+	r.overrides.Store(&next)
+}
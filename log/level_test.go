@@ -0,0 +1,63 @@
+package log_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sabariramc/goserverbase/log"
+	"gotest.tools/assert"
+)
+
+type recordingMux struct {
+	lock     sync.Mutex
+	messages []string
+}
+
+func (r *recordingMux) Print(ctx context.Context, msg *log.LogMessage) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.messages = append(r.messages, msg.ModuleName+":"+msg.ShortMessage)
+}
+
+func (r *recordingMux) getMessages() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]string(nil), r.messages...)
+}
+
+func (r *recordingMux) reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.messages = nil
+}
+
+func TestModuleLogLevel(t *testing.T) {
+	lMux := &recordingMux{}
+	logger := log.NewLogger(context.TODO(), &log.Config{ServiceName: "test", LogLevel: int(log.INFO)}, "app", lMux, nil)
+	kafkaLogger := logger.NewResourceLogger("kafka")
+	log.SetModuleLogLevel("kafka", log.DEBUG, time.Millisecond*50)
+	defer log.ResetModuleLogLevel("kafka")
+	logger.Debug(context.Background(), "hidden", nil)
+	kafkaLogger.Debug(context.Background(), "visible", nil)
+	assert.DeepEqual(t, lMux.getMessages(), []string{"kafka:visible"})
+	level, ok := log.GetModuleLogLevel("kafka")
+	assert.Assert(t, ok)
+	assert.Equal(t, level, log.DEBUG)
+	assert.Equal(t, len(log.GetModuleLogLevels()), 1)
+	assert.Assert(t, log.GetModuleLogLevels()[0].ExpiresAt != nil)
+	time.Sleep(time.Millisecond * 100)
+	_, ok = log.GetModuleLogLevel("kafka")
+	assert.Assert(t, !ok)
+	kafkaLogger.Debug(context.Background(), "hidden", nil)
+	assert.DeepEqual(t, lMux.getMessages(), []string{"kafka:visible"})
+}
+
+func TestParseLogLevel(t *testing.T) {
+	level, err := log.ParseLogLevel("warning")
+	assert.NilError(t, err)
+	assert.Equal(t, level, log.WARNING)
+	_, err = log.ParseLogLevel("verbose")
+	assert.Assert(t, err != nil)
+}
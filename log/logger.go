@@ -1,9 +1,11 @@
 package log
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"reflect"
 	"time"
 )
@@ -51,10 +53,14 @@ type LogMessage struct {
 	LogLevel
 	ShortMessage    string
 	FullMessage     string
+	FullMessageJSON json.RawMessage
 	FullMessageType string
 	Timestamp       time.Time
 	ModuleName      string
 	ServiceName     string
+	Fields          map[string]any
+	Caller          string
+	BelowLevel      bool
 }
 
 type Logger struct {
@@ -65,6 +71,9 @@ type Logger struct {
 	serviceName string
 	config      *Config
 	audit       AuditLogWriter
+	redactor    *Redactor
+	sampler     *Sampler
+	fields      map[string]any
 }
 
 func NewLogger(ctx context.Context, lc *Config, moduleName string, lMux LogMux, audit AuditLogWriter) *Logger {
@@ -79,7 +88,12 @@ func NewLogger(ctx context.Context, lc *Config, moduleName string, lMux LogMux,
 			Host:        lc.Host,
 			ServiceName: lc.ServiceName,
 		},
-		audit: audit,
+		audit:    audit,
+		redactor: NewDefaultRedactor(lc),
+	}
+	if lc.Sampling != nil && lc.Sampling.Initial > 0 {
+		l.sampler = NewSampler(*lc.Sampling)
+		l.sampler.Run(func(summary []SampledCount) { l.writeSampleSummary(ctx, summary) })
 	}
 	logLevel := lc.LogLevel
 	if logLevel > int(DEBUG) || logLevel < int(EMERGENCY) {
@@ -90,10 +104,35 @@ func NewLogger(ctx context.Context, lc *Config, moduleName string, lMux LogMux,
 	return l
 }
 
+func (l *Logger) GetLogLevel() LogLevelCode {
+	if level, ok := GetModuleLogLevel(l.moduleName); ok {
+		return level
+	}
+	return l.logLevel
+}
+
+func (l *Logger) levelEnabled(level LogLevelCode) bool {
+	if level <= l.GetLogLevel() {
+		return true
+	}
+	m, ok := l.lMux.(AllLevelWriter)
+	return ok && m.AcceptsAllLevels()
+}
+
+func (l *Logger) NewResourceLogger(moduleName string) *Logger {
+	if l == nil {
+		return nil
+	}
+	child := *l
+	child.moduleName = moduleName
+	return &child
+}
+
 func (l *Logger) SetModuleName(moduleName string) {
 	l.moduleName = moduleName
 }
 
+// Audit passes msg to the audit writer unchanged, the writers in log/audit wrap it in an AuditRecord themselves
 func (l *Logger) Audit(ctx context.Context, msg interface{}) error {
 	if l.audit == nil {
 		return nil
@@ -135,11 +174,56 @@ func (l *Logger) Emergency(ctx context.Context, shortMessage string, fullMessage
 }
 
 func (l *Logger) print(ctx context.Context, level *LogLevel, shortMessage string, fullMessage interface{}) {
-	if level.Level > l.logLevel {
+	if !l.levelEnabled(level.Level) {
 		return
 	}
+	caller := ""
+	if l.config.EnableCaller {
+		caller = getCaller(2)
+	}
+	l.sample(ctx, level, shortMessage, fullMessage, caller)
+}
+
+func (l *Logger) sample(ctx context.Context, level *LogLevel, shortMessage string, fullMessage interface{}, caller string) {
+	if l.sampler != nil && level.Level <= l.GetLogLevel() {
+		allow, summary := l.sampler.Sample(level.Level, l.moduleName, shortMessage)
+		l.writeSampleSummary(ctx, summary)
+		if !allow {
+			return
+		}
+	}
+	l.write(ctx, level, shortMessage, fullMessage, caller)
+}
+
+func (l *Logger) writeSampleSummary(ctx context.Context, summary []SampledCount) {
+	if len(summary) > 0 {
+		l.write(ctx, logLevelMap[NOTICE], "Log sampling summary", summary, "")
+	}
+}
+
+func (l *Logger) Name() string {
+	return "Logger"
+}
+
+// Shutdown stops the sampler and writes the final sampling summary, register it before the log mux so it runs first
+func (l *Logger) Shutdown(ctx context.Context) error {
+	if l.sampler != nil {
+		l.writeSampleSummary(ctx, l.sampler.Stop())
+	}
+	return nil
+}
+
+func (l *Logger) write(ctx context.Context, level *LogLevel, shortMessage string, fullMessage interface{}, caller string) {
 	var msg string
 	var msgType string
+	var msgJSON json.RawMessage
+	shortMessage = l.redactor.RedactString(shortMessage)
+	switch v := fullMessage.(type) {
+	case slog.Attr:
+		fullMessage = AttrsToMap(v)
+	case []slog.Attr:
+		fullMessage = AttrsToMap(v...)
+	}
 	if fullMessage == nil {
 		msg = shortMessage
 		msgType = "nil"
@@ -147,15 +231,18 @@ func (l *Logger) print(ctx context.Context, level *LogLevel, shortMessage string
 		msgType = reflect.TypeOf(fullMessage).Name()
 		switch v := fullMessage.(type) {
 		case string:
-			msg = v
+			msg = l.redactor.RedactString(v)
 		case error:
-			msg = v.Error()
+			msg = l.redactor.RedactString(v.Error())
 		default:
-			blob, err := json.MarshalIndent(v, "", "    ")
+			blob, err := l.redactor.Marshal(v)
 			if err != nil {
 				msg = fmt.Sprintf("%v - %v", ParseErrorMsg, err)
 			} else {
-				msg = string(blob)
+				msgJSON = blob
+				var indented bytes.Buffer
+				json.Indent(&indented, blob, "", "    ")
+				msg = indented.String()
 			}
 		}
 	}
@@ -163,10 +250,14 @@ func (l *Logger) print(ctx context.Context, level *LogLevel, shortMessage string
 		LogLevel:        *level,
 		ShortMessage:    shortMessage,
 		FullMessage:     msg,
+		FullMessageJSON: msgJSON,
 		FullMessageType: msgType,
 		Timestamp:       time.Now(),
 		ModuleName:      l.moduleName,
 		ServiceName:     l.serviceName,
+		Fields:          l.redactor.RedactFields(mergeFields(l.fields, GetFields(ctx))),
+		Caller:          caller,
+		BelowLevel:      level.Level > l.GetLogLevel(),
 	}
 	l.lMux.Print(ctx, message)
 }
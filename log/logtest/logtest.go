@@ -0,0 +1,142 @@
+package logtest
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sabariramc/goserverbase/log"
+)
+
+type CapturedLog struct {
+	Ctx           context.Context
+	CorrelationId string
+	log.LogMessage
+}
+
+type CaptureWriter struct {
+	lock    sync.RWMutex
+	entries []CapturedLog
+	written atomic.Uint64
+}
+
+func NewCaptureWriter() *CaptureWriter {
+	return &CaptureWriter{}
+}
+
+func (c *CaptureWriter) Start(logChannel chan log.MuxLogMessage) {
+	for msg := range logChannel {
+		_ = c.WriteMessage(msg.Ctx, &msg.LogMessage)
+		c.written.Add(1)
+	}
+}
+
+func (c *CaptureWriter) Written() uint64 {
+	return c.written.Load()
+}
+
+func (c *CaptureWriter) GetBufferSize() int {
+	return 1
+}
+
+func (c *CaptureWriter) WriteMessage(ctx context.Context, l *log.LogMessage) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries = append(c.entries, CapturedLog{Ctx: ctx, CorrelationId: log.GetCorrelationParam(ctx).CorrelationId, LogMessage: *l})
+	return nil
+}
+
+func (c *CaptureWriter) Entries() []CapturedLog {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return append([]CapturedLog{}, c.entries...)
+}
+
+func (c *CaptureWriter) Reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries = nil
+}
+
+func (c *CaptureWriter) ForCorrelation(correlationId string) *CaptureWriter {
+	res := &CaptureWriter{}
+	for _, entry := range c.Entries() {
+		if entry.CorrelationId == correlationId {
+			res.entries = append(res.entries, entry)
+		}
+	}
+	return res
+}
+
+func (c *CaptureWriter) Find(level log.LogLevelCode, shortMessagePattern string) []CapturedLog {
+	re := regexp.MustCompile(shortMessagePattern)
+	res := make([]CapturedLog, 0)
+	for _, entry := range c.Entries() {
+		if entry.Level == level && re.MatchString(entry.ShortMessage) {
+			res = append(res, entry)
+		}
+	}
+	return res
+}
+
+func (c *CaptureWriter) AssertLogged(t testing.TB, level log.LogLevelCode, shortMessagePattern string) CapturedLog {
+	t.Helper()
+	found := c.Find(level, shortMessagePattern)
+	if len(found) == 0 {
+		t.Fatalf("no %v log matching %q, captured:\n%v", log.GetLogLevelMap(level).LogLevelName, shortMessagePattern, c)
+	}
+	return found[0]
+}
+
+func (c *CaptureWriter) AssertNotLogged(t testing.TB, level log.LogLevelCode, shortMessagePattern string) {
+	t.Helper()
+	if found := c.Find(level, shortMessagePattern); len(found) > 0 {
+		t.Fatalf("unexpected %v log matching %q: %v", log.GetLogLevelMap(level).LogLevelName, shortMessagePattern, found[0].ShortMessage)
+	}
+}
+
+func (c *CaptureWriter) String() string {
+	var res string
+	for _, entry := range c.Entries() {
+		res += fmt.Sprintf("[%v] [%v] [%v] %v\n", entry.LogLevelName, entry.CorrelationId, entry.ModuleName, entry.ShortMessage)
+	}
+	return res
+}
+
+type TestWriter struct {
+	t    testing.TB
+	done atomic.Bool
+}
+
+func NewTestWriter(t testing.TB) *TestWriter {
+	w := &TestWriter{t: t}
+	t.Cleanup(func() { w.done.Store(true) })
+	return w
+}
+
+func (w *TestWriter) Start(logChannel chan log.MuxLogMessage) {
+	for msg := range logChannel {
+		_ = w.WriteMessage(msg.Ctx, &msg.LogMessage)
+	}
+}
+
+func (w *TestWriter) GetBufferSize() int {
+	return 1
+}
+
+func (w *TestWriter) WriteMessage(ctx context.Context, l *log.LogMessage) error {
+	if w.done.Load() {
+		return nil
+	}
+	w.t.Logf("[%v] [%v] [%v] [%v] [%v] %v", l.LogLevelName, log.GetCorrelationParam(ctx).CorrelationId, l.ModuleName, l.Caller, l.ShortMessage, l.FullMessage)
+	return nil
+}
+
+func NewTestLogger(t testing.TB) (*log.Logger, *CaptureWriter) {
+	capture := NewCaptureWriter()
+	lc := &log.Config{ServiceName: t.Name(), LogLevel: int(log.DEBUG), EnableCaller: true}
+	return log.NewLogger(context.Background(), lc, t.Name(), log.NewDefaultLogMux(capture, NewTestWriter(t)), nil), capture
+}
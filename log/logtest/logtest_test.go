@@ -0,0 +1,42 @@
+package logtest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/log/logtest"
+	"gotest.tools/assert"
+)
+
+func TestCaptureWriter(t *testing.T) {
+	logger, capture := logtest.NewTestLogger(t)
+	ctx1 := context.WithValue(context.Background(), log.ContextKeyCorrelation, &log.CorrelationParam{CorrelationId: "corr-1"})
+	ctx2 := context.WithValue(context.Background(), log.ContextKeyCorrelation, &log.CorrelationParam{CorrelationId: "corr-2"})
+	logger.Info(ctx1, "Order 1 created", map[string]any{"orderId": 1})
+	logger.Error(ctx2, "Order 2 failed", "timeout")
+	logger.Debug(ctx1, "Order 1 detail", nil)
+
+	entry := capture.AssertLogged(t, log.INFO, `^Order \d+ created$`)
+	assert.Equal(t, entry.CorrelationId, "corr-1")
+	assert.Equal(t, string(entry.FullMessageJSON), `{"orderId":1}`)
+	capture.AssertLogged(t, log.ERROR, "failed")
+	capture.AssertNotLogged(t, log.WARNING, ".*")
+
+	corr1 := capture.ForCorrelation("corr-1")
+	assert.Equal(t, len(corr1.Entries()), 2)
+	corr1.AssertLogged(t, log.DEBUG, "detail")
+	corr1.AssertNotLogged(t, log.ERROR, "failed")
+
+	capture.Reset()
+	assert.Equal(t, len(capture.Entries()), 0)
+}
+
+func TestChanneledCaptureWriter(t *testing.T) {
+	capture := logtest.NewCaptureWriter()
+	mux := log.NewChanneledLogMux(10, capture)
+	logger := log.NewLogger(context.Background(), &log.Config{ServiceName: "test", LogLevel: int(log.INFO)}, "CaptureTest", mux, nil)
+	logger.Warning(context.Background(), "Disk almost full", nil)
+	assert.NilError(t, mux.Flush(context.Background()))
+	capture.AssertLogged(t, log.WARNING, "Disk")
+}
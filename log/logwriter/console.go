@@ -5,10 +5,12 @@ import (
 	"fmt"
 
 	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/trace"
 )
 
 type ConsoleWriter struct {
 	BaseLogWriter
+	writeCounter
 }
 
 func NewConsoleWriter(hostParam log.HostParams) *ConsoleWriter {
@@ -20,6 +22,7 @@ func NewConsoleWriter(hostParam log.HostParams) *ConsoleWriter {
 func (c *ConsoleWriter) Start(logChannel chan log.MuxLogMessage) {
 	for log := range logChannel {
 		_ = c.WriteMessage(log.Ctx, &log.LogMessage)
+		c.written.Add(1)
 	}
 }
 
@@ -29,6 +32,6 @@ func (c *ConsoleWriter) GetBufferSize() int {
 
 func (c *ConsoleWriter) WriteMessage(ctx context.Context, l *log.LogMessage) error {
 	cr := log.GetCorrelationParam(ctx)
-	fmt.Printf("[%v] [%v] [%v] [%v] [%v] [%v] [%v] [%v]\n", l.Timestamp, l.LogLevelName, cr.CorrelationId, l.ServiceName, l.ModuleName, l.ShortMessage, l.FullMessageType, l.FullMessage)
+	fmt.Printf("[%v] [%v] [%v] [%v] [%v] [%v] [%v] [%v] [%v] [%v] [%v]\n", l.Timestamp, l.LogLevelName, cr.CorrelationId, l.ServiceName, l.ModuleName, l.ShortMessage, l.FullMessageType, l.FullMessage, trace.GetTraceID(ctx), l.Caller, formatFields(l.Fields))
 	return nil
 }
@@ -0,0 +1,286 @@
+package logwriter
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/sabariramc/goserverbase/log"
+)
+
+const fileBackupTimeFormat = "20060102T150405.000000000"
+
+type FileConfig struct {
+	Path             string
+	MaxSize          int64
+	RotationInterval time.Duration
+	MaxBackups       int
+	Compress         bool
+	BufferSize       int
+}
+
+type FileWriter struct {
+	BaseLogWriter
+	writeCounter
+	config   FileConfig
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	lock     sync.Mutex
+	sighup   chan os.Signal
+	done     chan struct{}
+	closed   bool
+	// archiveLock serialises compression and backup cleanup, which run outside lock so writes don't wait on gzip
+	archiveLock sync.Mutex
+	archiving   sync.WaitGroup
+}
+
+func NewFileWriter(hostParam log.HostParams, config FileConfig) (*FileWriter, error) {
+	if config.Path == "" {
+		return nil, fmt.Errorf("NewFileWriter: file path is required")
+	}
+	f := &FileWriter{
+		BaseLogWriter: BaseLogWriter{hostParam: &hostParam},
+		config:        config,
+		sighup:        make(chan os.Signal, 1),
+		done:          make(chan struct{}),
+	}
+	if err := f.open(); err != nil {
+		return nil, fmt.Errorf("NewFileWriter: %w", err)
+	}
+	signal.Notify(f.sighup, syscall.SIGHUP)
+	go f.watchSignal()
+	return f, nil
+}
+
+func (f *FileWriter) Start(logChannel chan log.MuxLogMessage) {
+	for log := range logChannel {
+		if err := f.WriteMessage(log.Ctx, &log.LogMessage); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+		}
+		f.written.Add(1)
+	}
+	f.Close()
+}
+
+func (f *FileWriter) GetBufferSize() int {
+	return f.config.BufferSize
+}
+
+func (f *FileWriter) WriteMessage(ctx context.Context, l *log.LogMessage) error {
+	blob, err := json.Marshal(NewJSONLogMessage(ctx, f.hostParam, l))
+	if err != nil {
+		return fmt.Errorf("FileWriter.WriteMessage: %w", err)
+	}
+	blob = append(blob, '\n')
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.closed {
+		return fmt.Errorf("FileWriter.WriteMessage: writer closed")
+	}
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return fmt.Errorf("FileWriter.WriteMessage: %w", err)
+		}
+	}
+	if f.shouldRotate(int64(len(blob))) {
+		if err := f.rotate(); err != nil {
+			fmt.Fprintf(os.Stderr, "FileWriter.WriteMessage: %v\n", err)
+			if f.file == nil {
+				return fmt.Errorf("FileWriter.WriteMessage: %w", err)
+			}
+		}
+	}
+	n, err := f.file.Write(blob)
+	f.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("FileWriter.WriteMessage: %w", err)
+	}
+	return nil
+}
+
+// Flush waits for an in progress write and syncs the file to disk
+func (f *FileWriter) Flush(ctx context.Context) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.closed || f.file == nil {
+		return nil
+	}
+	if err := f.file.Sync(); err != nil {
+		return fmt.Errorf("FileWriter.Flush: %w", err)
+	}
+	return nil
+}
+
+func (f *FileWriter) Reopen() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.closed {
+		return nil
+	}
+	if f.file != nil {
+		f.file.Close()
+		f.file = nil
+	}
+	if err := f.open(); err != nil {
+		return fmt.Errorf("FileWriter.Reopen: %w", err)
+	}
+	return nil
+}
+
+// Close closes the file and waits for pending backup compression
+func (f *FileWriter) Close() error {
+	err := f.close()
+	f.archiving.Wait()
+	return err
+}
+
+func (f *FileWriter) close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.closed {
+		return nil
+	}
+	f.closed = true
+	signal.Stop(f.sighup)
+	close(f.done)
+	if f.file == nil {
+		return nil
+	}
+	return f.file.Close()
+}
+
+func (f *FileWriter) watchSignal() {
+	for {
+		select {
+		case <-f.sighup:
+			if err := f.Reopen(); err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+			}
+		case <-f.done:
+			return
+		}
+	}
+}
+
+func (f *FileWriter) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.config.Path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(f.config.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = time.Now()
+	return nil
+}
+
+func (f *FileWriter) shouldRotate(writeSize int64) bool {
+	if f.config.MaxSize > 0 && f.size > 0 && f.size+writeSize > f.config.MaxSize {
+		return true
+	}
+	return f.config.RotationInterval > 0 && time.Since(f.openedAt) >= f.config.RotationInterval
+}
+
+// rotate moves the current file to a backup and opens a fresh one, on failure it falls back to reopening the original path.
+// f.file is left nil only when the path can't be opened at all, WriteMessage retries the open on the next message
+func (f *FileWriter) rotate() error {
+	f.file.Close()
+	f.file = nil
+	backup := f.config.Path + "." + time.Now().UTC().Format(fileBackupTimeFormat)
+	if err := os.Rename(f.config.Path, backup); err != nil {
+		return errors.Join(err, f.open())
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	f.archiving.Add(1)
+	go f.archive(backup)
+	return nil
+}
+
+func (f *FileWriter) archive(backup string) {
+	defer f.archiving.Done()
+	f.archiveLock.Lock()
+	defer f.archiveLock.Unlock()
+	if f.config.Compress {
+		if err := compressFile(backup); err != nil && !errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "FileWriter.archive: %v\n", err)
+		}
+	}
+	if err := f.removeOldBackups(); err != nil {
+		fmt.Fprintf(os.Stderr, "FileWriter.archive: %v\n", err)
+	}
+}
+
+func (f *FileWriter) removeOldBackups() error {
+	if f.config.MaxBackups <= 0 {
+		return nil
+	}
+	matches, err := filepath.Glob(f.config.Path + ".*")
+	if err != nil {
+		return err
+	}
+	backups := make([]string, 0, len(matches))
+	for _, match := range matches {
+		suffix := strings.TrimSuffix(strings.TrimPrefix(match, f.config.Path+"."), ".gz")
+		if _, err := time.Parse(fileBackupTimeFormat, suffix); err == nil {
+			backups = append(backups, match)
+		}
+	}
+	if len(backups) <= f.config.MaxBackups {
+		return nil
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return strings.TrimSuffix(backups[i], ".gz") > strings.TrimSuffix(backups[j], ".gz")
+	})
+	for _, backup := range backups[f.config.MaxBackups:] {
+		if err := os.Remove(backup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,75 @@
+package logwriter_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/log/logwriter"
+	"gotest.tools/assert"
+)
+
+func TestFileWriterRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	writer, err := logwriter.NewFileWriter(log.HostParams{Host: "localhost"}, logwriter.FileConfig{Path: path, MaxSize: 512, MaxBackups: 2, Compress: true})
+	assert.NilError(t, err)
+	lMux := log.NewChanneledLogMux(10, writer)
+	logger := log.NewLogger(context.TODO(), &log.Config{ServiceName: "test", LogLevel: int(log.DEBUG)}, "FileTest", lMux, nil)
+	for i := 0; i < 20; i++ {
+		logger.Info(context.Background(), "rotate", strings.Repeat("x", 100))
+	}
+	assert.NilError(t, lMux.Close(context.Background()))
+	backups, err := filepath.Glob(path + ".*.gz")
+	assert.NilError(t, err)
+	assert.Equal(t, len(backups), 2)
+	info, err := os.Stat(path)
+	assert.NilError(t, err)
+	assert.Assert(t, info.Size() <= 512)
+}
+
+func TestFileWriterReopenOnSIGHUP(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	writer, err := logwriter.NewFileWriter(log.HostParams{Host: "localhost"}, logwriter.FileConfig{Path: path})
+	assert.NilError(t, err)
+	defer writer.Close()
+	msg := &log.LogMessage{LogLevel: log.GetLogLevelMap(log.INFO), ShortMessage: "before", Timestamp: time.Now()}
+	assert.NilError(t, writer.WriteMessage(context.Background(), msg))
+	assert.NilError(t, os.Rename(path, path+".1"))
+	assert.NilError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	for i := 0; i < 50; i++ {
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		time.Sleep(time.Millisecond * 20)
+	}
+	msg.ShortMessage = "after"
+	assert.NilError(t, writer.WriteMessage(context.Background(), msg))
+	blob, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(blob), `"shortMessage":"after"`), string(blob))
+	assert.Assert(t, !strings.Contains(string(blob), `"shortMessage":"before"`), string(blob))
+}
+
+func TestFileWriterRecoversFromFailedRotation(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	path := filepath.Join(dir, "app.log")
+	writer, err := logwriter.NewFileWriter(log.HostParams{Host: "localhost"}, logwriter.FileConfig{Path: path, MaxSize: 64})
+	assert.NilError(t, err)
+	defer writer.Close()
+	msg := &log.LogMessage{LogLevel: log.GetLogLevelMap(log.INFO), ShortMessage: "before", Timestamp: time.Now()}
+	assert.NilError(t, writer.WriteMessage(context.Background(), msg))
+	assert.NilError(t, os.Rename(dir, dir+".moved"))
+	assert.NilError(t, os.WriteFile(dir, nil, 0644))
+	assert.Assert(t, writer.WriteMessage(context.Background(), msg) != nil)
+	assert.NilError(t, os.Remove(dir))
+	msg.ShortMessage = "after"
+	assert.NilError(t, writer.WriteMessage(context.Background(), msg))
+	blob, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(blob), `"shortMessage":"after"`), string(blob))
+}
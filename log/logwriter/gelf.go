@@ -0,0 +1,303 @@
+package logwriter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/trace"
+)
+
+const GELFVersion = "1.1"
+
+const (
+	DefaultGELFChunkSize           = 1420
+	DefaultGELFDialTimeout         = time.Second * 5
+	DefaultGELFWriteTimeout        = time.Second * 5
+	DefaultGELFMaxReconnectBackoff = time.Minute
+	gelfMinReconnectBackoff        = time.Second
+	gelfMaxChunks                  = 128
+	gelfChunkHeaderSize            = 12
+)
+
+var gelfChunkMagic = []byte{0x1e, 0x0f}
+
+var gelfFieldName = regexp.MustCompile(`[^\w.\-]`)
+
+var ErrGELFMessageTooLarge = fmt.Errorf("gelf message exceeds %v chunks", gelfMaxChunks)
+
+var ErrGELFUnavailable = fmt.Errorf("gelf server unavailable, waiting to reconnect")
+
+type GELFCompression int
+
+const (
+	GELFCompressionNone GELFCompression = iota
+	GELFCompressionGzip
+	GELFCompressionZlib
+)
+
+type GELFConfig struct {
+	Network     string
+	Address     string
+	Compression GELFCompression
+	ChunkSize   int
+	BufferSize  int
+	DialTimeout time.Duration
+	// WriteTimeout and MaxReconnectBackoff apply to tcp, messages are dropped with ErrGELFUnavailable while waiting to reconnect
+	WriteTimeout        time.Duration
+	MaxReconnectBackoff time.Duration
+}
+
+type GELFWriter struct {
+	BaseLogWriter
+	writeCounter
+	config  GELFConfig
+	conn    net.Conn
+	lock    sync.Mutex
+	backoff time.Duration
+	retryAt time.Time
+}
+
+func NewGELFWriter(hostParam log.HostParams, config GELFConfig) (*GELFWriter, error) {
+	if config.Network == "" {
+		config.Network = "udp"
+	}
+	if config.Network != "udp" && config.Network != "tcp" {
+		return nil, fmt.Errorf("NewGELFWriter: unsupported network %v", config.Network)
+	}
+	if config.ChunkSize <= gelfChunkHeaderSize {
+		config.ChunkSize = DefaultGELFChunkSize
+	}
+	if config.DialTimeout <= 0 {
+		config.DialTimeout = DefaultGELFDialTimeout
+	}
+	if config.WriteTimeout <= 0 {
+		config.WriteTimeout = DefaultGELFWriteTimeout
+	}
+	if config.MaxReconnectBackoff <= 0 {
+		config.MaxReconnectBackoff = DefaultGELFMaxReconnectBackoff
+	}
+	g := &GELFWriter{
+		BaseLogWriter: BaseLogWriter{hostParam: &hostParam},
+		config:        config,
+	}
+	if config.Network == "udp" {
+		if err := g.connect(); err != nil {
+			return nil, fmt.Errorf("NewGELFWriter: %w", err)
+		}
+	}
+	return g, nil
+}
+
+func (g *GELFWriter) Start(logChannel chan log.MuxLogMessage) {
+	for log := range logChannel {
+		if err := g.WriteMessage(log.Ctx, &log.LogMessage); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+		}
+		g.written.Add(1)
+	}
+}
+
+func (g *GELFWriter) GetBufferSize() int {
+	return g.config.BufferSize
+}
+
+func (g *GELFWriter) WriteMessage(ctx context.Context, l *log.LogMessage) error {
+	blob, err := json.Marshal(g.newGELFMessage(ctx, l))
+	if err != nil {
+		return fmt.Errorf("GELFWriter.WriteMessage: %w", err)
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.config.Network == "tcp" {
+		err = g.writeTCP(blob)
+	} else {
+		err = g.writeUDP(blob)
+	}
+	if err != nil {
+		return fmt.Errorf("GELFWriter.WriteMessage: %w", err)
+	}
+	return nil
+}
+
+func (g *GELFWriter) Close() error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.conn == nil {
+		return nil
+	}
+	err := g.conn.Close()
+	g.conn = nil
+	return err
+}
+
+func (g *GELFWriter) newGELFMessage(ctx context.Context, l *log.LogMessage) map[string]any {
+	msg := map[string]any{
+		"version":       GELFVersion,
+		"host":          g.hostParam.Host,
+		"short_message": l.ShortMessage,
+		"full_message":  l.FullMessage,
+		"timestamp":     float64(l.Timestamp.UnixMilli()) / 1000,
+		"level":         l.Level,
+	}
+	for key, value := range l.Fields {
+		key = gelfFieldName.ReplaceAllString(key, "_")
+		if key == "id" {
+			continue
+		}
+		switch value.(type) {
+		case string, bool, float64, float32, int, int64, int32, uint, uint64, uint32, json.Number:
+			msg["_"+key] = value
+		default:
+			blob, _ := json.Marshal(value)
+			msg["_"+key] = string(blob)
+		}
+	}
+	correlation := log.GetCorrelationParam(ctx)
+	customer := log.GetCustomerIdentifier(ctx)
+	additional := map[string]string{
+		"service":           l.ServiceName,
+		"module":            l.ModuleName,
+		"app_version":       g.hostParam.Version,
+		"full_message_type": l.FullMessageType,
+		"correlation_id":    correlation.CorrelationId,
+		"scenario_id":       correlation.ScenarioId,
+		"scenario_name":     correlation.ScenarioName,
+		"session_id":        correlation.SessionId,
+		"customer_id":       customer.CustomerId,
+		"app_user_id":       customer.AppUserId,
+		"entity_id":         customer.Id,
+		"trace_id":          trace.GetTraceID(ctx),
+		"caller":            l.Caller,
+	}
+	for key, value := range additional {
+		if value != "" {
+			msg["_"+key] = value
+		}
+	}
+	return msg
+}
+
+func (g *GELFWriter) connect() error {
+	conn, err := net.DialTimeout(g.config.Network, g.config.Address, g.config.DialTimeout)
+	if err != nil {
+		return err
+	}
+	g.conn = conn
+	if g.config.Network == "tcp" {
+		go g.watch(conn)
+	}
+	return nil
+}
+
+func (g *GELFWriter) watch(conn net.Conn) {
+	io.Copy(io.Discard, conn)
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.conn == conn {
+		g.conn.Close()
+		g.conn = nil
+	}
+}
+
+func (g *GELFWriter) writeTCP(blob []byte) error {
+	frame := append(blob, 0)
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if g.conn == nil {
+			if err = g.reconnect(); err != nil {
+				return err
+			}
+		}
+		g.conn.SetWriteDeadline(time.Now().Add(g.config.WriteTimeout))
+		if _, err = g.conn.Write(frame); err == nil {
+			return nil
+		}
+		g.conn.Close()
+		g.conn = nil
+	}
+	return err
+}
+
+// reconnect backs off exponentially after a failed dial so an unreachable server doesn't cost DialTimeout per message
+func (g *GELFWriter) reconnect() error {
+	if time.Now().Before(g.retryAt) {
+		return ErrGELFUnavailable
+	}
+	if err := g.connect(); err != nil {
+		g.backoff = min(max(g.backoff*2, gelfMinReconnectBackoff), g.config.MaxReconnectBackoff)
+		g.retryAt = time.Now().Add(g.backoff)
+		return err
+	}
+	g.backoff = 0
+	return nil
+}
+
+func (g *GELFWriter) writeUDP(blob []byte) error {
+	payload, err := g.compress(blob)
+	if err != nil {
+		return err
+	}
+	if g.conn == nil {
+		if err := g.connect(); err != nil {
+			return err
+		}
+	}
+	if len(payload) <= g.config.ChunkSize {
+		_, err = g.conn.Write(payload)
+		return err
+	}
+	chunkDataSize := g.config.ChunkSize - gelfChunkHeaderSize
+	count := (len(payload) + chunkDataSize - 1) / chunkDataSize
+	if count > gelfMaxChunks {
+		return ErrGELFMessageTooLarge
+	}
+	messageId := make([]byte, 8)
+	rand.Read(messageId)
+	chunk := make([]byte, 0, g.config.ChunkSize)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * chunkDataSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+		chunk = append(chunk[:0], gelfChunkMagic...)
+		chunk = append(chunk, messageId...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunk = append(chunk, payload[i*chunkDataSize:end]...)
+		if _, err := g.conn.Write(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *GELFWriter) compress(blob []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch g.config.Compression {
+	case GELFCompressionGzip:
+		w = gzip.NewWriter(&buf)
+	case GELFCompressionZlib:
+		w = zlib.NewWriter(&buf)
+	default:
+		return blob, nil
+	}
+	if _, err := w.Write(blob); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
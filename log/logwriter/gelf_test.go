@@ -0,0 +1,155 @@
+package logwriter_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/log/logwriter"
+	"gotest.tools/assert"
+)
+
+func gelfContext() context.Context {
+	ctx := context.WithValue(context.Background(), log.ContextKeyCorrelation, &log.CorrelationParam{CorrelationId: "corr-1"})
+	return context.WithValue(ctx, log.ContextKeyCustomerIdentifier, &log.CustomerIdentifier{CustomerId: "cust-1"})
+}
+
+func readUDPMessage(t *testing.T, conn net.PacketConn) []byte {
+	buf := make([]byte, 65536)
+	chunks := map[byte][]byte{}
+	for {
+		conn.SetReadDeadline(time.Now().Add(time.Second * 5))
+		n, _, err := conn.ReadFrom(buf)
+		assert.NilError(t, err)
+		packet := append([]byte(nil), buf[:n]...)
+		if len(packet) < 2 || packet[0] != 0x1e || packet[1] != 0x0f {
+			return packet
+		}
+		chunks[packet[10]] = packet[12:]
+		if len(chunks) == int(packet[11]) {
+			var res []byte
+			for i := 0; i < len(chunks); i++ {
+				res = append(res, chunks[byte(i)]...)
+			}
+			return res
+		}
+	}
+}
+
+func TestGELFWriterUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer conn.Close()
+	hostParams := log.HostParams{Version: "1.1", Host: "localhost", ServiceName: "test"}
+	writer, err := logwriter.NewGELFWriter(hostParams, logwriter.GELFConfig{Address: conn.LocalAddr().String()})
+	assert.NilError(t, err)
+	defer writer.Close()
+	logger := log.NewLogger(context.TODO(), &log.Config{ServiceName: "test", LogLevel: int(log.DEBUG)}, "GELFTest", log.NewDefaultLogMux(writer), nil)
+	logger.Warning(gelfContext(), "small", "message")
+	var msg map[string]any
+	assert.NilError(t, json.Unmarshal(readUDPMessage(t, conn), &msg))
+	assert.Equal(t, msg["version"], "1.1")
+	assert.Equal(t, msg["host"], "localhost")
+	assert.Equal(t, msg["short_message"], "small")
+	assert.Equal(t, msg["level"], float64(log.WARNING))
+	assert.Equal(t, msg["_correlation_id"], "corr-1")
+	assert.Equal(t, msg["_customer_id"], "cust-1")
+	assert.Equal(t, msg["_module"], "GELFTest")
+
+	writer, err = logwriter.NewGELFWriter(hostParams, logwriter.GELFConfig{Address: conn.LocalAddr().String(), Compression: logwriter.GELFCompressionGzip, ChunkSize: 100})
+	assert.NilError(t, err)
+	defer writer.Close()
+	long := strings.Repeat("0123456789abcdef", 1000)
+	assert.NilError(t, writer.WriteMessage(gelfContext(), &log.LogMessage{LogLevel: log.GetLogLevelMap(log.ERROR), ShortMessage: "large", FullMessage: long, Timestamp: time.Now()}))
+	gz, err := gzip.NewReader(bytes.NewReader(readUDPMessage(t, conn)))
+	assert.NilError(t, err)
+	blob, err := io.ReadAll(gz)
+	assert.NilError(t, err)
+	assert.NilError(t, json.Unmarshal(blob, &msg))
+	assert.Equal(t, msg["short_message"], "large")
+	assert.Equal(t, msg["full_message"], long)
+}
+
+func TestGELFWriterTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer listener.Close()
+	received := make(chan string, 2)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			frame, err := bufio.NewReader(conn).ReadString(0)
+			if err == nil {
+				received <- strings.TrimSuffix(frame, "\x00")
+			}
+			conn.Close()
+		}
+	}()
+	writer, err := logwriter.NewGELFWriter(log.HostParams{Host: "localhost"}, logwriter.GELFConfig{Network: "tcp", Address: listener.Addr().String()})
+	assert.NilError(t, err)
+	defer writer.Close()
+	var msg map[string]any
+	for _, shortMessage := range []string{"first", "second"} {
+		assert.NilError(t, writer.WriteMessage(gelfContext(), &log.LogMessage{LogLevel: log.GetLogLevelMap(log.INFO), ShortMessage: shortMessage, Timestamp: time.Now()}))
+		select {
+		case frame := <-received:
+			assert.NilError(t, json.Unmarshal([]byte(frame), &msg))
+			assert.Equal(t, msg["short_message"], shortMessage)
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out waiting for gelf message")
+		}
+		time.Sleep(time.Millisecond * 50)
+	}
+}
+
+func TestGELFWriterTCPReconnectBackoff(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	address := listener.Addr().String()
+	assert.NilError(t, listener.Close())
+	writer, err := logwriter.NewGELFWriter(log.HostParams{Host: "localhost"}, logwriter.GELFConfig{Network: "tcp", Address: address, MaxReconnectBackoff: time.Millisecond * 200})
+	assert.NilError(t, err)
+	defer writer.Close()
+	msg := &log.LogMessage{LogLevel: log.GetLogLevelMap(log.INFO), ShortMessage: "down", Timestamp: time.Now()}
+	err = writer.WriteMessage(gelfContext(), msg)
+	assert.Assert(t, err != nil && !errors.Is(err, logwriter.ErrGELFUnavailable), err)
+	err = writer.WriteMessage(gelfContext(), msg)
+	assert.Assert(t, errors.Is(err, logwriter.ErrGELFUnavailable), err)
+
+	listener, err = net.Listen("tcp", address)
+	assert.NilError(t, err)
+	defer listener.Close()
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		frame, err := bufio.NewReader(conn).ReadString(0)
+		if err == nil {
+			received <- strings.TrimSuffix(frame, "\x00")
+		}
+	}()
+	time.Sleep(time.Millisecond * 250)
+	msg.ShortMessage = "up"
+	assert.NilError(t, writer.WriteMessage(gelfContext(), msg))
+	select {
+	case frame := <-received:
+		assert.Assert(t, strings.Contains(frame, `"short_message":"up"`), frame)
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for gelf message")
+	}
+}
@@ -0,0 +1,99 @@
+package logwriter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/trace"
+)
+
+type JSONLogMessage struct {
+	Timestamp       string                  `json:"timestamp"`
+	Level           string                  `json:"level"`
+	LevelCode       log.LogLevelCode        `json:"levelCode"`
+	ServiceName     string                  `json:"service"`
+	ModuleName      string                  `json:"module"`
+	Host            string                  `json:"host"`
+	Version         string                  `json:"version"`
+	Correlation     *log.CorrelationParam   `json:"correlation"`
+	Customer        *log.CustomerIdentifier `json:"customer"`
+	TraceID         string                  `json:"traceId,omitempty"`
+	ShortMessage    string                  `json:"shortMessage"`
+	FullMessageType string                  `json:"fullMessageType"`
+	FullMessage     any                     `json:"fullMessage"`
+	Fields          map[string]any          `json:"fields,omitempty"`
+	Caller          string                  `json:"caller,omitempty"`
+}
+
+type JSONWriter struct {
+	BaseLogWriter
+	writeCounter
+	out  io.Writer
+	lock sync.Mutex
+}
+
+func NewJSONWriter(hostParam log.HostParams, out io.Writer) *JSONWriter {
+	if out == nil {
+		out = os.Stdout
+	}
+	return &JSONWriter{
+		BaseLogWriter: BaseLogWriter{hostParam: &hostParam},
+		out:           out,
+	}
+}
+
+func (j *JSONWriter) Start(logChannel chan log.MuxLogMessage) {
+	for log := range logChannel {
+		_ = j.WriteMessage(log.Ctx, &log.LogMessage)
+		j.written.Add(1)
+	}
+}
+
+func (j *JSONWriter) GetBufferSize() int {
+	return 1
+}
+
+func (j *JSONWriter) WriteMessage(ctx context.Context, l *log.LogMessage) error {
+	blob, err := json.Marshal(NewJSONLogMessage(ctx, j.hostParam, l))
+	if err != nil {
+		return fmt.Errorf("JSONWriter.WriteMessage: %w", err)
+	}
+	blob = append(blob, '\n')
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	_, err = j.out.Write(blob)
+	if err != nil {
+		return fmt.Errorf("JSONWriter.WriteMessage: %w", err)
+	}
+	return nil
+}
+
+func NewJSONLogMessage(ctx context.Context, hostParam *log.HostParams, l *log.LogMessage) *JSONLogMessage {
+	msg := &JSONLogMessage{
+		Timestamp:       l.Timestamp.UTC().Format(time.RFC3339Nano),
+		Level:           l.LogLevelName,
+		LevelCode:       l.Level,
+		ServiceName:     l.ServiceName,
+		ModuleName:      l.ModuleName,
+		Host:            hostParam.Host,
+		Version:         hostParam.Version,
+		Correlation:     log.GetCorrelationParam(ctx),
+		Customer:        log.GetCustomerIdentifier(ctx),
+		TraceID:         trace.GetTraceID(ctx),
+		ShortMessage:    l.ShortMessage,
+		FullMessageType: l.FullMessageType,
+		FullMessage:     l.FullMessage,
+		Fields:          l.Fields,
+		Caller:          l.Caller,
+	}
+	if len(l.FullMessageJSON) > 0 {
+		msg.FullMessage = l.FullMessageJSON
+	}
+	return msg
+}
@@ -0,0 +1,43 @@
+package logwriter_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/log/logwriter"
+	"gotest.tools/assert"
+)
+
+func TestJSONWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	hostParams := log.HostParams{Version: "1.1", Host: "localhost", ServiceName: "test"}
+	logger := log.NewLogger(context.TODO(), &log.Config{ServiceName: "test", LogLevel: int(log.DEBUG)}, "JSONTest", log.NewDefaultLogMux(logwriter.NewJSONWriter(hostParams, buf)), nil)
+	ctx := context.WithValue(context.Background(), log.ContextKeyCorrelation, &log.CorrelationParam{CorrelationId: "corr-1", ScenarioId: "scenario"})
+	ctx = context.WithValue(ctx, log.ContextKeyCustomerIdentifier, &log.CustomerIdentifier{CustomerId: "cust-1"})
+	logger.With("orderId", "o-1").Info(ctx, "structured", map[string]any{"key": "value", "count": 2})
+	logger.Error(ctx, "plain", "text message")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, len(lines), 2)
+	var msg map[string]any
+	assert.NilError(t, json.Unmarshal([]byte(lines[0]), &msg))
+	_, err := time.Parse(time.RFC3339Nano, msg["timestamp"].(string))
+	assert.NilError(t, err)
+	assert.Equal(t, msg["level"], "INFO")
+	assert.Equal(t, msg["levelCode"], float64(log.INFO))
+	assert.Equal(t, msg["service"], "test")
+	assert.Equal(t, msg["module"], "JSONTest")
+	assert.Equal(t, msg["host"], "localhost")
+	assert.Equal(t, msg["version"], "1.1")
+	assert.DeepEqual(t, msg["correlation"], map[string]any{"x-correlation-id": "corr-1", "x-scenario-id": "scenario"})
+	assert.Equal(t, msg["customer"].(map[string]any)["x-customer-id"], "cust-1")
+	assert.DeepEqual(t, msg["fullMessage"], map[string]any{"key": "value", "count": float64(2)})
+	assert.DeepEqual(t, msg["fields"], map[string]any{"orderId": "o-1"})
+	assert.NilError(t, json.Unmarshal([]byte(lines[1]), &msg))
+	assert.Equal(t, msg["level"], "ERROR")
+	assert.Equal(t, msg["fullMessage"], "text message")
+}
@@ -0,0 +1,286 @@
+package logwriter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	cKafka "github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/sabariramc/goserverbase/log"
+)
+
+const (
+	DefaultKafkaBatchSize     = 100
+	DefaultKafkaFlushInterval = time.Second
+	DefaultKafkaCloseTimeout  = time.Second * 5
+)
+
+var ErrKafkaWriterClosed = fmt.Errorf("kafka log writer closed")
+
+type KafkaProducer interface {
+	Produce(msg *cKafka.Message, deliveryChan chan cKafka.Event) error
+}
+
+type KafkaConfig struct {
+	Topic         string
+	BatchSize     int
+	FlushInterval time.Duration
+	BufferSize    int
+	CloseTimeout  time.Duration
+	Fallback      io.Writer
+}
+
+type KafkaWriter struct {
+	BaseLogWriter
+	writeCounter
+	config    KafkaConfig
+	producer  KafkaProducer
+	queue     chan *JSONLogMessage
+	delivery  chan cKafka.Event
+	pending   atomic.Int64
+	closed    atomic.Bool
+	batchDone chan struct{}
+	flushReq  chan chan struct{}
+	stop      chan struct{}
+	closeOnce sync.Once
+	lock      sync.Mutex
+	fbLock    sync.Mutex
+}
+
+func NewKafkaWriter(hostParam log.HostParams, producer KafkaProducer, config KafkaConfig) *KafkaWriter {
+	if config.BatchSize < 1 {
+		config.BatchSize = DefaultKafkaBatchSize
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = DefaultKafkaFlushInterval
+	}
+	if config.CloseTimeout <= 0 {
+		config.CloseTimeout = DefaultKafkaCloseTimeout
+	}
+	if config.Fallback == nil {
+		config.Fallback = os.Stderr
+	}
+	k := &KafkaWriter{
+		BaseLogWriter: BaseLogWriter{hostParam: &hostParam},
+		config:        config,
+		producer:      producer,
+		queue:         make(chan *JSONLogMessage, config.BatchSize*2),
+		delivery:      make(chan cKafka.Event, config.BatchSize),
+		batchDone:     make(chan struct{}),
+		flushReq:      make(chan chan struct{}),
+		stop:          make(chan struct{}),
+	}
+	go k.batch()
+	go k.deliveryReport()
+	return k
+}
+
+func (k *KafkaWriter) Start(logChannel chan log.MuxLogMessage) {
+	for log := range logChannel {
+		_ = k.WriteMessage(log.Ctx, &log.LogMessage)
+		k.written.Add(1)
+	}
+	if err := k.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+	}
+}
+
+func (k *KafkaWriter) GetBufferSize() int {
+	return k.config.BufferSize
+}
+
+func (k *KafkaWriter) WriteMessage(ctx context.Context, l *log.LogMessage) error {
+	msg := NewJSONLogMessage(ctx, k.hostParam, l)
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	if k.closed.Load() {
+		k.fallback(msg)
+		return fmt.Errorf("KafkaWriter.WriteMessage: %w", ErrKafkaWriterClosed)
+	}
+	select {
+	case k.queue <- msg:
+	default:
+		k.fallback(msg)
+	}
+	return nil
+}
+
+func (k *KafkaWriter) Close() error {
+	k.closeOnce.Do(func() {
+		k.lock.Lock()
+		k.closed.Store(true)
+		close(k.queue)
+		k.lock.Unlock()
+	})
+	<-k.batchDone
+	defer close(k.stop)
+	timeout := time.NewTimer(k.config.CloseTimeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(time.Millisecond * 10)
+	defer ticker.Stop()
+	for k.pending.Load() > 0 {
+		select {
+		case <-ticker.C:
+		case <-timeout.C:
+			return fmt.Errorf("KafkaWriter.Close: %v log records not delivered", k.pending.Load())
+		}
+	}
+	return nil
+}
+
+// Flush produces the queued and batched records and waits for their delivery reports
+func (k *KafkaWriter) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	select {
+	case k.flushReq <- done:
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return fmt.Errorf("KafkaWriter.Flush: %w", ctx.Err())
+		}
+	case <-k.batchDone:
+	case <-ctx.Done():
+		return fmt.Errorf("KafkaWriter.Flush: %w", ctx.Err())
+	}
+	ticker := time.NewTicker(time.Millisecond * 10)
+	defer ticker.Stop()
+	for k.pending.Load() > 0 {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("KafkaWriter.Flush: %v log records not delivered: %w", k.pending.Load(), ctx.Err())
+		}
+	}
+	return nil
+}
+
+func (k *KafkaWriter) batch() {
+	defer close(k.batchDone)
+	ticker := time.NewTicker(k.config.FlushInterval)
+	defer ticker.Stop()
+	batch := make([]*JSONLogMessage, 0, k.config.BatchSize)
+	for {
+		select {
+		case msg, ok := <-k.queue:
+			if !ok {
+				k.produce(batch)
+				return
+			}
+			batch = append(batch, msg)
+			if len(batch) >= k.config.BatchSize {
+				k.produce(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			k.produce(batch)
+			batch = batch[:0]
+		case done := <-k.flushReq:
+			for drained := false; !drained; {
+				select {
+				case msg, ok := <-k.queue:
+					if ok {
+						batch = append(batch, msg)
+					} else {
+						drained = true
+					}
+				default:
+					drained = true
+				}
+			}
+			k.produce(batch)
+			batch = batch[:0]
+			close(done)
+		}
+	}
+}
+
+func (k *KafkaWriter) produce(batch []*JSONLogMessage) {
+	if len(batch) == 0 {
+		return
+	}
+	keys := make([]string, 0)
+	records := make(map[string][]*JSONLogMessage)
+	for _, msg := range batch {
+		key := msg.Correlation.CorrelationId
+		if _, ok := records[key]; !ok {
+			keys = append(keys, key)
+		}
+		records[key] = append(records[key], msg)
+	}
+	for _, key := range keys {
+		blob, err := json.Marshal(records[key])
+		if err != nil {
+			k.writeFallback("KafkaWriter.produce: %v\n", err)
+			continue
+		}
+		msg := &cKafka.Message{
+			TopicPartition: cKafka.TopicPartition{Topic: &k.config.Topic, Partition: cKafka.PartitionAny},
+			Value:          blob,
+			Timestamp:      time.Now(),
+		}
+		if key != "" {
+			msg.Key = []byte(key)
+		}
+		k.pending.Add(1)
+		err = k.producer.Produce(msg, k.delivery)
+		if err != nil {
+			k.pending.Add(-1)
+			k.fallbackRecord(blob, err)
+		}
+	}
+}
+
+// deliveryReport keeps draining after Close until every produced batch is reported, a blocked delivery channel would stall the shared producer
+func (k *KafkaWriter) deliveryReport() {
+	stop := k.stop
+	for {
+		select {
+		case e := <-k.delivery:
+			if m, ok := e.(*cKafka.Message); ok {
+				if m.TopicPartition.Error != nil {
+					k.fallbackRecord(m.Value, m.TopicPartition.Error)
+				}
+				k.pending.Add(-1)
+			}
+		case <-stop:
+			stop = nil
+		}
+		if stop == nil && k.pending.Load() == 0 {
+			return
+		}
+	}
+}
+
+func (k *KafkaWriter) fallback(msg *JSONLogMessage) {
+	blob, err := json.Marshal(msg)
+	if err != nil {
+		k.writeFallback("KafkaWriter.fallback: %v\n", err)
+		return
+	}
+	k.writeFallback("%s\n", blob)
+}
+
+func (k *KafkaWriter) fallbackRecord(blob []byte, err error) {
+	k.fbLock.Lock()
+	defer k.fbLock.Unlock()
+	var batch []json.RawMessage
+	if json.Unmarshal(blob, &batch) != nil {
+		fmt.Fprintf(k.config.Fallback, "%s\n", blob)
+		return
+	}
+	fmt.Fprintf(k.config.Fallback, "KafkaWriter: produce failed, writing %v log records to fallback: %v\n", len(batch), err)
+	for _, msg := range batch {
+		fmt.Fprintf(k.config.Fallback, "%s\n", msg)
+	}
+}
+
+func (k *KafkaWriter) writeFallback(format string, args ...any) {
+	k.fbLock.Lock()
+	defer k.fbLock.Unlock()
+	fmt.Fprintf(k.config.Fallback, format, args...)
+}
@@ -0,0 +1,152 @@
+package logwriter_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cKafka "github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/log/logwriter"
+	"gotest.tools/assert"
+)
+
+type fakeKafkaProducer struct {
+	lock       sync.Mutex
+	messages   []*cKafka.Message
+	enqueueErr error
+	deliverErr error
+	gate       chan struct{}
+	delivered  atomic.Int32
+}
+
+func (f *fakeKafkaProducer) Produce(msg *cKafka.Message, deliveryChan chan cKafka.Event) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.enqueueErr != nil {
+		return f.enqueueErr
+	}
+	f.messages = append(f.messages, msg)
+	msg.TopicPartition.Error = f.deliverErr
+	go func() {
+		if f.gate != nil {
+			<-f.gate
+		}
+		deliveryChan <- msg
+		f.delivered.Add(1)
+	}()
+	return nil
+}
+
+type syncBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.buf.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.buf.String()
+}
+
+func correlationContext(id string) context.Context {
+	return context.WithValue(context.Background(), log.ContextKeyCorrelation, &log.CorrelationParam{CorrelationId: id})
+}
+
+func TestKafkaWriterBatchesByCorrelation(t *testing.T) {
+	producer := &fakeKafkaProducer{}
+	fallback := &syncBuffer{}
+	writer := logwriter.NewKafkaWriter(log.HostParams{ServiceName: "test"}, producer, logwriter.KafkaConfig{Topic: "logs", BatchSize: 4, FlushInterval: time.Hour, Fallback: fallback})
+	logger := log.NewLogger(context.TODO(), &log.Config{ServiceName: "test", LogLevel: int(log.INFO)}, "KafkaTest", log.NewDefaultLogMux(writer), nil)
+	for i := 0; i < 3; i++ {
+		logger.Info(correlationContext("corr-1"), fmt.Sprintf("first %v", i), nil)
+		logger.Info(correlationContext("corr-2"), fmt.Sprintf("second %v", i), nil)
+	}
+	assert.NilError(t, writer.Close())
+	assert.Equal(t, fallback.String(), "")
+	records := make(map[string][]string)
+	for _, m := range producer.messages {
+		assert.Equal(t, *m.TopicPartition.Topic, "logs")
+		var batch []logwriter.JSONLogMessage
+		assert.NilError(t, json.Unmarshal(m.Value, &batch))
+		for _, msg := range batch {
+			assert.Equal(t, msg.Correlation.CorrelationId, string(m.Key))
+			records[string(m.Key)] = append(records[string(m.Key)], msg.ShortMessage)
+		}
+	}
+	assert.Equal(t, len(producer.messages), 4)
+	assert.DeepEqual(t, records, map[string][]string{
+		"corr-1": {"first 0", "first 1", "first 2"},
+		"corr-2": {"second 0", "second 1", "second 2"},
+	})
+}
+
+func TestKafkaWriterFallback(t *testing.T) {
+	producer := &fakeKafkaProducer{deliverErr: cKafka.NewError(cKafka.ErrTransport, "broker down", false)}
+	fallback := &syncBuffer{}
+	writer := logwriter.NewKafkaWriter(log.HostParams{ServiceName: "test"}, producer, logwriter.KafkaConfig{Topic: "logs", BatchSize: 10, FlushInterval: time.Millisecond * 10, Fallback: fallback})
+	logger := log.NewLogger(context.TODO(), &log.Config{ServiceName: "test", LogLevel: int(log.INFO)}, "KafkaTest", log.NewDefaultLogMux(writer), nil)
+	logger.Error(correlationContext("corr-1"), "delivery failed", nil)
+	assert.NilError(t, writer.Close())
+	assert.Assert(t, strings.Contains(fallback.String(), "broker down"), fallback.String())
+	assert.Assert(t, strings.Contains(fallback.String(), `"shortMessage":"delivery failed"`), fallback.String())
+
+	producer = &fakeKafkaProducer{enqueueErr: cKafka.NewError(cKafka.ErrQueueFull, "queue full", false)}
+	fallback = &syncBuffer{}
+	writer = logwriter.NewKafkaWriter(log.HostParams{ServiceName: "test"}, producer, logwriter.KafkaConfig{Topic: "logs", Fallback: fallback})
+	assert.NilError(t, writer.WriteMessage(correlationContext("corr-2"), &log.LogMessage{ShortMessage: "enqueue failed", Timestamp: time.Now()}))
+	assert.NilError(t, writer.Close())
+	assert.Assert(t, strings.Contains(fallback.String(), `"shortMessage":"enqueue failed"`), fallback.String())
+	assert.Equal(t, writer.WriteMessage(context.Background(), &log.LogMessage{ShortMessage: "after close"}) != nil, true)
+	assert.Assert(t, strings.Contains(fallback.String(), `"shortMessage":"after close"`), fallback.String())
+}
+
+func TestKafkaWriterDrainsDeliveryAfterCloseTimeout(t *testing.T) {
+	producer := &fakeKafkaProducer{gate: make(chan struct{})}
+	writer := logwriter.NewKafkaWriter(log.HostParams{ServiceName: "test"}, producer, logwriter.KafkaConfig{Topic: "logs", BatchSize: 3, FlushInterval: time.Hour, CloseTimeout: time.Millisecond * 20, Fallback: &syncBuffer{}})
+	assert.NilError(t, writer.WriteMessage(context.Background(), &log.LogMessage{ShortMessage: "no correlation", Timestamp: time.Now()}))
+	for i := 0; i < 5; i++ {
+		assert.NilError(t, writer.WriteMessage(correlationContext(fmt.Sprintf("corr-%v", i)), &log.LogMessage{ShortMessage: "held", Timestamp: time.Now()}))
+	}
+	assert.ErrorContains(t, writer.Close(), "not delivered")
+	close(producer.gate)
+	for i := 0; i < 100 && producer.delivered.Load() < 6; i++ {
+		time.Sleep(time.Millisecond * 10)
+	}
+	assert.Equal(t, producer.delivered.Load(), int32(6))
+	producer.lock.Lock()
+	defer producer.lock.Unlock()
+	assert.Assert(t, producer.messages[0].Key == nil)
+	assert.Equal(t, string(producer.messages[1].Key), "corr-0")
+}
+
+func TestKafkaWriterFlush(t *testing.T) {
+	producer := &fakeKafkaProducer{}
+	writer := logwriter.NewKafkaWriter(log.HostParams{ServiceName: "test"}, producer, logwriter.KafkaConfig{Topic: "logs", FlushInterval: time.Hour, Fallback: &syncBuffer{}})
+	lMux := log.NewChanneledLogMux(10, writer)
+	lMux.Print(correlationContext("corr-1"), &log.LogMessage{ShortMessage: "batched", Timestamp: time.Now()})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NilError(t, lMux.Flush(ctx))
+	producer.lock.Lock()
+	assert.Equal(t, len(producer.messages), 1)
+	producer.lock.Unlock()
+	lMux.Print(correlationContext("corr-2"), &log.LogMessage{ShortMessage: "on shutdown", Timestamp: time.Now()})
+	assert.NilError(t, lMux.Close(ctx))
+	producer.lock.Lock()
+	defer producer.lock.Unlock()
+	assert.Equal(t, len(producer.messages), 2)
+	assert.Equal(t, producer.delivered.Load(), int32(2))
+}
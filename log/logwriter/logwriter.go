@@ -1,7 +1,33 @@
 package logwriter
 
-import "github.com/sabariramc/goserverbase/log"
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/sabariramc/goserverbase/log"
+)
 
 type BaseLogWriter struct {
 	hostParam *log.HostParams
 }
+
+// writeCounter counts messages finished by a writer's Start loop, it implements log.ProgressWriter
+type writeCounter struct {
+	written atomic.Uint64
+}
+
+func (w *writeCounter) Written() uint64 {
+	return w.written.Load()
+}
+
+func formatFields(fields map[string]any) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	blob, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Sprintf("%v", fields)
+	}
+	return string(blob)
+}
@@ -0,0 +1,84 @@
+package logwriter
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sabariramc/goserverbase/log"
+)
+
+const DefaultRingSize = 10000
+
+type ringEntry struct {
+	seq uint64
+	msg *JSONLogMessage
+}
+
+type RingWriter struct {
+	BaseLogWriter
+	writeCounter
+	entries []ringEntry
+	index   map[string][]uint64
+	next    uint64
+	lock    sync.RWMutex
+}
+
+func NewRingWriter(hostParam log.HostParams, size int) *RingWriter {
+	if size < 1 {
+		size = DefaultRingSize
+	}
+	return &RingWriter{
+		BaseLogWriter: BaseLogWriter{hostParam: &hostParam},
+		entries:       make([]ringEntry, size),
+		index:         make(map[string][]uint64),
+	}
+}
+
+func (r *RingWriter) Start(logChannel chan log.MuxLogMessage) {
+	for log := range logChannel {
+		_ = r.WriteMessage(log.Ctx, &log.LogMessage)
+		r.written.Add(1)
+	}
+}
+
+func (r *RingWriter) GetBufferSize() int {
+	return 0
+}
+
+func (r *RingWriter) AcceptsAllLevels() bool {
+	return true
+}
+
+func (r *RingWriter) WriteMessage(ctx context.Context, l *log.LogMessage) error {
+	msg := NewJSONLogMessage(ctx, r.hostParam, l)
+	id := msg.Correlation.CorrelationId
+	if id == "" {
+		return nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	slot := r.next % uint64(len(r.entries))
+	if old := r.entries[slot].msg; old != nil {
+		oldId := old.Correlation.CorrelationId
+		if seqs := r.index[oldId][1:]; len(seqs) > 0 {
+			r.index[oldId] = seqs
+		} else {
+			delete(r.index, oldId)
+		}
+	}
+	r.entries[slot] = ringEntry{seq: r.next, msg: msg}
+	r.index[id] = append(r.index[id], r.next)
+	r.next++
+	return nil
+}
+
+func (r *RingWriter) GetLogs(correlationId string) []*JSONLogMessage {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	seqs := r.index[correlationId]
+	res := make([]*JSONLogMessage, 0, len(seqs))
+	for _, seq := range seqs {
+		res = append(res, r.entries[seq%uint64(len(r.entries))].msg)
+	}
+	return res
+}
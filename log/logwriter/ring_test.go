@@ -0,0 +1,52 @@
+package logwriter_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/log/logwriter"
+	"gotest.tools/assert"
+)
+
+func TestRingWriter(t *testing.T) {
+	hostParams := log.HostParams{ServiceName: "test"}
+	ring := logwriter.NewRingWriter(hostParams, 4)
+	buf := &bytes.Buffer{}
+	logger := log.NewLogger(context.TODO(), &log.Config{ServiceName: "test", LogLevel: int(log.INFO)}, "RingTest", log.NewDefaultLogMux(ring, logwriter.NewJSONWriter(hostParams, buf)), nil)
+	logger.Debug(correlationContext("corr-1"), "debug 1", nil)
+	logger.Info(correlationContext("corr-1"), "info 1", nil)
+	logger.Info(correlationContext("corr-2"), "info 2", nil)
+	assert.Equal(t, strings.Count(buf.String(), "\n"), 2)
+	assert.Assert(t, !strings.Contains(buf.String(), "debug 1"))
+
+	logs := ring.GetLogs("corr-1")
+	assert.Equal(t, len(logs), 2)
+	assert.Equal(t, logs[0].ShortMessage, "debug 1")
+	assert.Equal(t, logs[0].Level, "DEBUG")
+	assert.Equal(t, logs[1].ShortMessage, "info 1")
+
+	for i := 0; i < 3; i++ {
+		logger.Debug(correlationContext("corr-2"), fmt.Sprintf("debug 2.%v", i), nil)
+	}
+	assert.Equal(t, len(ring.GetLogs("corr-1")), 0)
+	logs = ring.GetLogs("corr-2")
+	assert.Equal(t, len(logs), 4)
+	assert.Equal(t, logs[0].ShortMessage, "info 2")
+	assert.Equal(t, logs[3].ShortMessage, "debug 2.2")
+}
+
+func TestRingWriterChanneledMux(t *testing.T) {
+	ring := logwriter.NewRingWriter(log.HostParams{ServiceName: "test"}, 10)
+	buf := &syncBuffer{}
+	mux := log.NewChanneledLogMux(10, ring, logwriter.NewJSONWriter(log.HostParams{ServiceName: "test"}, buf))
+	logger := log.NewLogger(context.TODO(), &log.Config{ServiceName: "test", LogLevel: int(log.WARNING)}, "RingTest", mux, nil)
+	logger.Info(correlationContext("corr-1"), "info", nil)
+	logger.Error(correlationContext("corr-1"), "error", nil)
+	assert.NilError(t, mux.Flush(context.Background()))
+	assert.Equal(t, len(ring.GetLogs("corr-1")), 2)
+	assert.Equal(t, strings.Count(buf.String(), "\n"), 1)
+}
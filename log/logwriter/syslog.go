@@ -11,6 +11,7 @@ import (
 
 type SyslogWriter struct {
 	BaseLogWriter
+	writeCounter
 	logger *stlLog.Logger
 }
 
@@ -30,11 +31,12 @@ func (s *SyslogWriter) GetBufferSize() int {
 func (c *SyslogWriter) Start(logChannel chan log.MuxLogMessage) {
 	for log := range logChannel {
 		_ = c.WriteMessage(log.Ctx, &log.LogMessage)
+		c.written.Add(1)
 	}
 }
 
 func (c *SyslogWriter) WriteMessage(ctx context.Context, l *log.LogMessage) error {
 	cr := log.GetCorrelationParam(ctx)
-	c.logger.Printf("[%v] [%v] [%v] [%v] [%v] [%v] [%v]\n", l.Timestamp, l.LogLevelName, cr.CorrelationId, l.ServiceName, l.ShortMessage, l.FullMessageType, l.FullMessage)
+	c.logger.Printf("[%v] [%v] [%v] [%v] [%v] [%v] [%v] [%v] [%v]\n", l.Timestamp, l.LogLevelName, cr.CorrelationId, l.ServiceName, l.ShortMessage, l.FullMessageType, l.FullMessage, l.Caller, formatFields(l.Fields))
 	return nil
 }
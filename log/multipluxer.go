@@ -1,6 +1,14 @@
 package log
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const flushPollInterval = time.Millisecond * 10
 
 type ChanneledLogWriter interface {
 	Start(chan MuxLogMessage)
@@ -16,6 +24,21 @@ type LogMux interface {
 	Print(context.Context, *LogMessage)
 }
 
+type AllLevelWriter interface {
+	AcceptsAllLevels() bool
+}
+
+// FlushableWriter is implemented by writers that buffer internally, ChanneledLogMux.Flush calls it once the writer's channel is drained
+type FlushableWriter interface {
+	Flush(context.Context) error
+}
+
+// ProgressWriter is implemented by writers whose Start loop counts the messages it has finished writing, Flush uses it to wait for
+// a message the writer has taken off its channel but not yet written
+type ProgressWriter interface {
+	Written() uint64
+}
+
 type MuxLogMessage struct {
 	Ctx        context.Context
 	LogMessage LogMessage
@@ -24,25 +47,51 @@ type MuxLogMessage struct {
 type ChanneledLogMux struct {
 	inChannel  chan MuxLogMessage
 	outChannel []chan MuxLogMessage
+	outWriter  []*muxWriter
+	lock       sync.RWMutex
+	closed     bool
+	writerWg   sync.WaitGroup
+	inFlight   atomic.Int64
+}
+
+type muxWriter struct {
+	name      string
+	policy    OverflowPolicy
+	timeout   time.Duration
+	allLevels bool
+	flusher   FlushableWriter
+	progress  ProgressWriter
+	sent      atomic.Uint64
+	dropped   atomic.Uint64
 }
 
 func NewChanneledLogMux(bufferSize uint8, logWriterList ...ChanneledLogWriter) *ChanneledLogMux {
-	outChannelList := make([]chan MuxLogMessage, len(logWriterList))
+	ls := &ChanneledLogMux{inChannel: make(chan MuxLogMessage, bufferSize), outChannel: make([]chan MuxLogMessage, len(logWriterList)), outWriter: make([]*muxWriter, len(logWriterList))}
 	for i, logWriter := range logWriterList {
 		lBufferSize := logWriter.GetBufferSize()
 		if lBufferSize < 1 {
 			lBufferSize = int(bufferSize)
 		}
 		outChannel := make(chan MuxLogMessage, lBufferSize)
-		outChannelList[i] = outChannel
-		go logWriter.Start(outChannel)
+		ls.outChannel[i] = outChannel
+		ls.outWriter[i] = newMuxWriter(logWriter)
+		ls.writerWg.Add(1)
+		go func(w ChanneledLogWriter) {
+			defer ls.writerWg.Done()
+			w.Start(outChannel)
+		}(logWriter)
 	}
-	ls := &ChanneledLogMux{inChannel: make(chan MuxLogMessage, bufferSize), outChannel: outChannelList}
 	go ls.start()
 	return ls
 }
 
 func (ls *ChanneledLogMux) Print(ctx context.Context, msg *LogMessage) {
+	ls.lock.RLock()
+	defer ls.lock.RUnlock()
+	if ls.closed {
+		return
+	}
+	ls.inFlight.Add(1)
 	ls.inChannel <- MuxLogMessage{
 		Ctx:        ctx,
 		LogMessage: *msg,
@@ -51,10 +100,98 @@ func (ls *ChanneledLogMux) Print(ctx context.Context, msg *LogMessage) {
 
 func (ls *ChanneledLogMux) start() {
 	for log := range ls.inChannel {
-		for _, outChannel := range ls.outChannel {
-			outChannel <- log
+		for i, outChannel := range ls.outChannel {
+			if log.LogMessage.BelowLevel && !ls.outWriter[i].allLevels {
+				continue
+			}
+			ls.outWriter[i].send(outChannel, log)
+		}
+		ls.inFlight.Add(-1)
+	}
+	for _, outChannel := range ls.outChannel {
+		close(outChannel)
+	}
+}
+
+func (ls *ChanneledLogMux) Flush(ctx context.Context) error {
+	ticker := time.NewTicker(flushPollInterval)
+	defer ticker.Stop()
+	for !ls.isDrained() {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("ChanneledLogMux.Flush: %w", ctx.Err())
+		}
+	}
+	for _, w := range ls.outWriter {
+		if w.flusher == nil {
+			continue
+		}
+		if err := w.flusher.Flush(ctx); err != nil {
+			return fmt.Errorf("ChanneledLogMux.Flush: %v: %w", w.name, err)
+		}
+	}
+	return nil
+}
+
+func (ls *ChanneledLogMux) isDrained() bool {
+	if ls.inFlight.Load() > 0 {
+		return false
+	}
+	for i, outChannel := range ls.outChannel {
+		if len(outChannel) > 0 {
+			return false
+		}
+		if w := ls.outWriter[i]; w.progress != nil && w.progress.Written() < w.sent.Load() {
+			return false
 		}
 	}
+	return true
+}
+
+func (ls *ChanneledLogMux) GetDroppedCount() map[string]uint64 {
+	res := make(map[string]uint64, len(ls.outWriter))
+	for _, w := range ls.outWriter {
+		res[w.name] += w.dropped.Load()
+	}
+	return res
+}
+
+func (ls *ChanneledLogMux) AcceptsAllLevels() bool {
+	for _, w := range ls.outWriter {
+		if w.allLevels {
+			return true
+		}
+	}
+	return false
+}
+
+func (ls *ChanneledLogMux) Close(ctx context.Context) error {
+	ls.lock.Lock()
+	if !ls.closed {
+		ls.closed = true
+		close(ls.inChannel)
+	}
+	ls.lock.Unlock()
+	done := make(chan struct{})
+	go func() {
+		ls.writerWg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("ChanneledLogMux.Close: %w", ctx.Err())
+	}
+}
+
+func (ls *ChanneledLogMux) Name() string {
+	return "ChanneledLogMux"
+}
+
+func (ls *ChanneledLogMux) Shutdown(ctx context.Context) error {
+	return ls.Close(ctx)
 }
 
 type DefaultLogMux struct {
@@ -68,6 +205,26 @@ func NewDefaultLogMux(logWriterList ...LogWriter) *DefaultLogMux {
 
 func (ls *DefaultLogMux) Print(ctx context.Context, msg *LogMessage) {
 	for _, w := range ls.writer {
+		if msg.BelowLevel && !acceptsAllLevels(w) {
+			continue
+		}
 		_ = w.WriteMessage(ctx, msg)
 	}
 }
+
+func (ls *DefaultLogMux) AcceptsAllLevels() bool {
+	for _, w := range ls.writer {
+		if acceptsAllLevels(w) {
+			return true
+		}
+	}
+	return false
+}
+
+func acceptsAllLevels(w any) bool {
+	if o, ok := w.(*overflowPolicyWriter); ok {
+		w = o.ChanneledLogWriter
+	}
+	a, ok := w.(AllLevelWriter)
+	return ok && a.AcceptsAllLevels()
+}
@@ -0,0 +1,155 @@
+package log_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sabariramc/goserverbase/log"
+	"gotest.tools/assert"
+)
+
+type slowWriter struct {
+	release  chan struct{}
+	lock     sync.Mutex
+	received []string
+}
+
+func (s *slowWriter) Start(logChannel chan log.MuxLogMessage) {
+	<-s.release
+	for msg := range logChannel {
+		s.WriteMessage(msg.Ctx, &msg.LogMessage)
+	}
+}
+
+func (s *slowWriter) WriteMessage(ctx context.Context, l *log.LogMessage) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.received = append(s.received, l.ShortMessage)
+	return nil
+}
+
+func (s *slowWriter) GetBufferSize() int {
+	return 2
+}
+
+func (s *slowWriter) getReceived() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]string(nil), s.received...)
+}
+
+func TestChanneledLogMuxOverflowPolicy(t *testing.T) {
+	messages := []string{"1", "2", "3", "4", "5", "6"}
+	for _, tc := range []struct {
+		name     string
+		policy   log.OverflowPolicy
+		expected []string
+	}{
+		{"DropNewest", log.OverflowDropNewest, []string{"1", "2"}},
+		{"DropOldest", log.OverflowDropOldest, []string{"5", "6"}},
+		{"BlockWithTimeout", log.OverflowBlockWithTimeout, []string{"1", "2"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			writer := &slowWriter{release: make(chan struct{})}
+			lMux := log.NewChanneledLogMux(1, log.WithOverflowPolicy(writer, tc.policy, time.Millisecond))
+			start := time.Now()
+			for _, msg := range messages {
+				lMux.Print(context.Background(), &log.LogMessage{ShortMessage: msg})
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			for lMux.GetDroppedCount()["*log_test.slowWriter"] < 4 {
+				assert.NilError(t, ctx.Err())
+				time.Sleep(time.Millisecond)
+			}
+			assert.Assert(t, time.Since(start) < time.Second)
+			close(writer.release)
+			assert.NilError(t, lMux.Flush(ctx))
+			assert.NilError(t, lMux.Close(ctx))
+			assert.DeepEqual(t, writer.getReceived(), tc.expected)
+		})
+	}
+}
+
+func TestChanneledLogMuxClose(t *testing.T) {
+	writer := &slowWriter{release: make(chan struct{})}
+	lMux := log.NewChanneledLogMux(1, writer)
+	go func() {
+		time.Sleep(time.Millisecond * 50)
+		close(writer.release)
+	}()
+	lMux.Print(context.Background(), &log.LogMessage{ShortMessage: "1"})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NilError(t, lMux.Close(ctx))
+	assert.DeepEqual(t, writer.getReceived(), []string{"1"})
+	lMux.Print(context.Background(), &log.LogMessage{ShortMessage: "2"})
+	assert.DeepEqual(t, writer.getReceived(), []string{"1"})
+}
+
+type flushingWriter struct {
+	slowWriter
+	flushed []string
+}
+
+func (f *flushingWriter) Flush(ctx context.Context) error {
+	f.flushed = f.getReceived()
+	return nil
+}
+
+func TestChanneledLogMuxDefaultPolicyDoesNotBlock(t *testing.T) {
+	writer := &flushingWriter{slowWriter: slowWriter{release: make(chan struct{})}}
+	lMux := log.NewChanneledLogMux(1, writer)
+	done := make(chan struct{})
+	go func() {
+		for _, msg := range []string{"1", "2", "3", "4", "5", "6"} {
+			lMux.Print(context.Background(), &log.LogMessage{ShortMessage: msg})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Print blocked behind a slow writer")
+	}
+	close(writer.release)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NilError(t, lMux.Flush(ctx))
+	assert.Assert(t, len(writer.flushed) > 0)
+	assert.NilError(t, lMux.Close(ctx))
+	assert.Equal(t, lMux.GetDroppedCount()["*log_test.flushingWriter"], uint64(6-len(writer.getReceived())))
+}
+
+type progressWriter struct {
+	slowWriter
+	written atomic.Uint64
+}
+
+func (p *progressWriter) Start(logChannel chan log.MuxLogMessage) {
+	for msg := range logChannel {
+		time.Sleep(time.Millisecond * 20)
+		p.WriteMessage(msg.Ctx, &msg.LogMessage)
+		p.written.Add(1)
+	}
+}
+
+func (p *progressWriter) Written() uint64 {
+	return p.written.Load()
+}
+
+func TestChanneledLogMuxFlushWaitsForInProgressWrite(t *testing.T) {
+	writer := &progressWriter{}
+	lMux := log.NewChanneledLogMux(10, log.WithOverflowPolicy(writer, log.OverflowBlock, 0))
+	for _, msg := range []string{"1", "2", "3"} {
+		lMux.Print(context.Background(), &log.LogMessage{ShortMessage: msg})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NilError(t, lMux.Flush(ctx))
+	assert.DeepEqual(t, writer.getReceived(), []string{"1", "2", "3"})
+	assert.NilError(t, lMux.Close(ctx))
+}
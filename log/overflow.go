@@ -0,0 +1,118 @@
+package log
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sabariramc/goserverbase/metrics"
+)
+
+type OverflowPolicy int
+
+const (
+	OverflowBlock OverflowPolicy = iota
+	OverflowDropNewest
+	OverflowDropOldest
+	OverflowBlockWithTimeout
+)
+
+var DefaultOverflowTimeout = time.Millisecond * 100
+
+// DefaultOverflowPolicy applies to writers without an explicit policy, it never blocks the caller of Print behind a slow writer
+var DefaultOverflowPolicy = OverflowDropNewest
+
+var droppedMessages = metrics.NewCounterVec("log_messages_dropped_total", "Log messages dropped because a writer buffer was full", "writer")
+
+func init() {
+	metrics.MustRegister(droppedMessages)
+}
+
+type OverflowPolicyWriter interface {
+	GetOverflowPolicy() (OverflowPolicy, time.Duration)
+}
+
+type overflowPolicyWriter struct {
+	ChanneledLogWriter
+	policy  OverflowPolicy
+	timeout time.Duration
+}
+
+func (o *overflowPolicyWriter) GetOverflowPolicy() (OverflowPolicy, time.Duration) {
+	return o.policy, o.timeout
+}
+
+func WithOverflowPolicy(w ChanneledLogWriter, policy OverflowPolicy, timeout time.Duration) ChanneledLogWriter {
+	return &overflowPolicyWriter{ChanneledLogWriter: w, policy: policy, timeout: timeout}
+}
+
+func newMuxWriter(w ChanneledLogWriter) *muxWriter {
+	mw := &muxWriter{name: fmt.Sprintf("%T", w), policy: DefaultOverflowPolicy, allLevels: acceptsAllLevels(w)}
+	inner := w
+	if o, ok := w.(*overflowPolicyWriter); ok {
+		inner = o.ChanneledLogWriter
+		mw.name = fmt.Sprintf("%T", inner)
+	}
+	if f, ok := inner.(FlushableWriter); ok {
+		mw.flusher = f
+	}
+	if p, ok := inner.(ProgressWriter); ok {
+		mw.progress = p
+	}
+	if p, ok := w.(OverflowPolicyWriter); ok {
+		mw.policy, mw.timeout = p.GetOverflowPolicy()
+	}
+	if mw.policy == OverflowBlockWithTimeout && mw.timeout <= 0 {
+		mw.timeout = DefaultOverflowTimeout
+	}
+	return mw
+}
+
+func (mw *muxWriter) send(out chan MuxLogMessage, msg MuxLogMessage) {
+	switch mw.policy {
+	case OverflowDropNewest:
+		select {
+		case out <- msg:
+			mw.sent.Add(1)
+		default:
+			mw.drop()
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case out <- msg:
+				mw.sent.Add(1)
+				return
+			default:
+			}
+			select {
+			case <-out:
+				mw.sent.Add(^uint64(0))
+				mw.drop()
+			default:
+			}
+		}
+	case OverflowBlockWithTimeout:
+		select {
+		case out <- msg:
+			mw.sent.Add(1)
+			return
+		default:
+		}
+		timer := time.NewTimer(mw.timeout)
+		defer timer.Stop()
+		select {
+		case out <- msg:
+			mw.sent.Add(1)
+		case <-timer.C:
+			mw.drop()
+		}
+	default:
+		out <- msg
+		mw.sent.Add(1)
+	}
+}
+
+func (mw *muxWriter) drop() {
+	mw.dropped.Add(1)
+	droppedMessages.WithLabelValues(mw.name).Inc()
+}
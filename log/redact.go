@@ -0,0 +1,399 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	RedactedValue = "***REDACTED***"
+	TagLog        = "log"
+	TagLogRedact  = "redact"
+	TagLogMask    = "mask"
+	maskVisible   = 4
+)
+
+var DefaultRedactKeys = []string{
+	"password", "passwd", "secret", "secretString", "secretBinary", "secretKey", "clientSecret", "privateKey",
+	"token", "accessToken", "refreshToken", "idToken", "authorization", "apiKey", "x-api-key",
+	"plaintext", "cvv", "pin", "otp",
+}
+
+type Detector struct {
+	Name     string
+	Pattern  *regexp.Regexp
+	Validate func(match string) bool
+	Mask     func(match string) string
+}
+
+// DefaultDetectors mask values by pattern inside strings, they are opt-in through Config.RedactPatterns as bare numbers are too ambiguous to match safely.
+// Card numbers need a known issuer prefix and phone numbers need a country code so timestamps and numeric IDs are left alone
+var DefaultDetectors = []Detector{
+	{Name: "card", Pattern: regexp.MustCompile(`[2-6]\d{3}(?:[ -]?\d{4}){2}[ -]?\d{1,7}`), Validate: isCardNumber},
+	{Name: "email", Pattern: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`), Mask: maskEmail},
+	{Name: "aadhaar", Pattern: regexp.MustCompile(`[2-9]\d{3} \d{4} \d{4}`), Validate: isAadhaar},
+	{Name: "pan", Pattern: regexp.MustCompile(`[A-Z]{3}[ABCFGHLJPT][A-Z]\d{4}[A-Z]`)},
+	{Name: "phone", Pattern: regexp.MustCompile(`\+[1-9]\d{0,2}[ -]?\d{6,12}`)},
+}
+
+type pathRule struct {
+	segments []string
+	mask     bool
+}
+
+type Redactor struct {
+	keys      map[string]bool
+	paths     []pathRule
+	detectors []Detector
+}
+
+func NewRedactor(keys []string, paths []string, detectors []Detector) *Redactor {
+	r := &Redactor{keys: make(map[string]bool, len(keys)), detectors: detectors}
+	for _, key := range keys {
+		r.keys[normalizeKey(key)] = true
+	}
+	for _, path := range paths {
+		r.paths = append(r.paths, pathRule{segments: splitPath(path)})
+	}
+	return r
+}
+
+func NewDefaultRedactor(lc *Config) *Redactor {
+	if lc.DisableRedaction {
+		return nil
+	}
+	keys := append(append([]string{}, DefaultRedactKeys...), lc.RedactKeys...)
+	var detectors []Detector
+	if lc.RedactPatterns {
+		detectors = DefaultDetectors
+	}
+	return NewRedactor(keys, lc.RedactPaths, detectors)
+}
+
+func (r *Redactor) RedactString(val string) string {
+	if r == nil {
+		return val
+	}
+	for _, detector := range r.detectors {
+		val = detector.redact(val)
+	}
+	return val
+}
+
+func (r *Redactor) Marshal(val any) ([]byte, error) {
+	blob, err := json.Marshal(val)
+	if err != nil || r == nil {
+		return blob, err
+	}
+	return r.redactJSON(blob, tagRules(reflect.TypeOf(val)))
+}
+
+// RedactFields returns a copy of fields with sensitive values replaced, scalars keep their type and composite values that had something redacted are replaced with their redacted JSON
+func (r *Redactor) RedactFields(fields map[string]any) map[string]any {
+	if r == nil || len(fields) == 0 {
+		return fields
+	}
+	redacted := make(map[string]any, len(fields))
+	for key, val := range fields {
+		redacted[key] = r.redactField(key, val)
+	}
+	return redacted
+}
+
+func (r *Redactor) redactField(key string, val any) any {
+	if r.keys[normalizeKey(key)] {
+		return RedactedValue
+	}
+	path := []string{key}
+	for _, rule := range r.paths {
+		if rule.match(path) {
+			return rule.apply(val)
+		}
+	}
+	if val == nil {
+		return nil
+	}
+	switch v := reflect.ValueOf(val); v.Kind() {
+	case reflect.String:
+		if s := r.RedactString(v.String()); s != v.String() {
+			return s
+		}
+		return val
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct, reflect.Pointer, reflect.Interface:
+	default:
+		return val
+	}
+	blob, err := json.Marshal(val)
+	if err != nil {
+		return val
+	}
+	tree, err := decodeJSON(blob)
+	if err != nil {
+		return val
+	}
+	original, _ := json.Marshal(tree)
+	out, err := json.Marshal(r.walk(tree, path, r.paths))
+	if err != nil || bytes.Equal(original, out) {
+		return val
+	}
+	return json.RawMessage(out)
+}
+
+func (r *Redactor) RedactJSON(blob []byte) ([]byte, error) {
+	if r == nil {
+		return blob, nil
+	}
+	return r.redactJSON(blob, nil)
+}
+
+func (r *Redactor) redactJSON(blob []byte, extra []pathRule) ([]byte, error) {
+	tree, err := decodeJSON(blob)
+	if err != nil {
+		return nil, err
+	}
+	rules := append(append([]pathRule{}, r.paths...), extra...)
+	tree = r.walk(tree, nil, rules)
+	return json.Marshal(tree)
+}
+
+func decodeJSON(blob []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(blob))
+	decoder.UseNumber()
+	var tree any
+	err := decoder.Decode(&tree)
+	return tree, err
+}
+
+func (r *Redactor) walk(val any, path []string, rules []pathRule) any {
+	for _, rule := range rules {
+		if rule.match(path) {
+			return rule.apply(val)
+		}
+	}
+	switch v := val.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if r.keys[normalizeKey(key)] {
+				v[key] = RedactedValue
+				continue
+			}
+			v[key] = r.walk(item, append(path, key), rules)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = r.walk(item, append(path, "*"), rules)
+		}
+	case string:
+		return r.RedactString(v)
+	}
+	return val
+}
+
+func (p pathRule) match(path []string) bool {
+	if len(p.segments) != len(path) {
+		return false
+	}
+	for i, segment := range p.segments {
+		if segment != "*" && segment != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (p pathRule) apply(val any) any {
+	if p.mask {
+		if s, ok := val.(string); ok {
+			return MaskValue(s)
+		}
+		if n, ok := val.(json.Number); ok {
+			return MaskValue(n.String())
+		}
+	}
+	return RedactedValue
+}
+
+func (d Detector) redact(val string) string {
+	matches := d.Pattern.FindAllStringIndex(val, -1)
+	if len(matches) == 0 {
+		return val
+	}
+	var sb strings.Builder
+	last := 0
+	for _, m := range matches {
+		match := val[m[0]:m[1]]
+		if !isBoundary(val, m[0]-1) || !isBoundary(val, m[1]) || (d.Validate != nil && !d.Validate(match)) {
+			continue
+		}
+		sb.WriteString(val[last:m[0]])
+		if d.Mask != nil {
+			sb.WriteString(d.Mask(match))
+		} else {
+			sb.WriteString(MaskValue(match))
+		}
+		last = m[1]
+	}
+	sb.WriteString(val[last:])
+	return sb.String()
+}
+
+func MaskValue(val string) string {
+	runes := []rune(val)
+	if len(runes) <= maskVisible {
+		return strings.Repeat("*", len(runes))
+	}
+	return strings.Repeat("*", len(runes)-maskVisible) + string(runes[len(runes)-maskVisible:])
+}
+
+func maskEmail(val string) string {
+	local, domain, _ := strings.Cut(val, "@")
+	return local[:1] + strings.Repeat("*", len(local)-1) + "@" + domain
+}
+
+func isBoundary(val string, i int) bool {
+	if i < 0 || i >= len(val) {
+		return true
+	}
+	c := rune(val[i])
+	return !(unicode.IsLetter(c) || unicode.IsDigit(c) || c == '-' || c == '_')
+}
+
+func digitsOf(val string) []int {
+	digits := make([]int, 0, len(val))
+	for _, c := range val {
+		if c >= '0' && c <= '9' {
+			digits = append(digits, int(c-'0'))
+		}
+	}
+	return digits
+}
+
+func isCardNumber(val string) bool {
+	digits := digitsOf(val)
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := digits[i]
+		if (len(digits)-1-i)%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+var verhoeffMultiplication = [10][10]int{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+	{1, 2, 3, 4, 0, 6, 7, 8, 9, 5},
+	{2, 3, 4, 0, 1, 7, 8, 9, 5, 6},
+	{3, 4, 0, 1, 2, 8, 9, 5, 6, 7},
+	{4, 0, 1, 2, 3, 9, 5, 6, 7, 8},
+	{5, 9, 8, 7, 6, 0, 4, 3, 2, 1},
+	{6, 5, 9, 8, 7, 1, 0, 4, 3, 2},
+	{7, 6, 5, 9, 8, 2, 1, 0, 4, 3},
+	{8, 7, 6, 5, 9, 3, 2, 1, 0, 4},
+	{9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+}
+
+var verhoeffPermutation = [8][10]int{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+	{1, 5, 7, 6, 2, 8, 3, 0, 9, 4},
+	{5, 8, 0, 3, 7, 9, 6, 1, 4, 2},
+	{8, 9, 1, 6, 0, 4, 3, 5, 2, 7},
+	{9, 4, 5, 3, 1, 2, 6, 8, 7, 0},
+	{4, 2, 8, 6, 5, 7, 3, 9, 0, 1},
+	{2, 7, 9, 3, 8, 0, 6, 4, 1, 5},
+	{7, 0, 4, 6, 9, 1, 3, 2, 5, 8},
+}
+
+func isAadhaar(val string) bool {
+	digits := digitsOf(val)
+	if len(digits) != 12 {
+		return false
+	}
+	c := 0
+	for i := 0; i < len(digits); i++ {
+		c = verhoeffMultiplication[c][verhoeffPermutation[i%8][digits[len(digits)-1-i]]]
+	}
+	return c == 0
+}
+
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(key))
+}
+
+func splitPath(path string) []string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	path = strings.ReplaceAll(path, "[*]", ".*")
+	return strings.Split(path, ".")
+}
+
+var tagRuleCache sync.Map
+
+func tagRules(t reflect.Type) []pathRule {
+	if t == nil {
+		return nil
+	}
+	if cached, ok := tagRuleCache.Load(t); ok {
+		return cached.([]pathRule)
+	}
+	rules := make([]pathRule, 0)
+	collectTagRules(t, nil, &rules, map[reflect.Type]bool{})
+	tagRuleCache.Store(t, rules)
+	return rules
+}
+
+func collectTagRules(t reflect.Type, path []string, rules *[]pathRule, visiting map[reflect.Type]bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		collectTagRules(t.Elem(), append(path, "*"), rules, visiting)
+		return
+	case reflect.Struct:
+	default:
+		return
+	}
+	if visiting[t] {
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			collectTagRules(field.Type, path, rules, visiting)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fieldPath := append(append([]string{}, path...), name)
+		switch field.Tag.Get(TagLog) {
+		case TagLogRedact:
+			*rules = append(*rules, pathRule{segments: fieldPath})
+		case TagLogMask:
+			*rules = append(*rules, pathRule{segments: fieldPath, mask: true})
+		default:
+			collectTagRules(field.Type, fieldPath, rules, visiting)
+		}
+	}
+}
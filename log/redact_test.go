@@ -0,0 +1,100 @@
+package log_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sabariramc/goserverbase/log"
+	"gotest.tools/assert"
+)
+
+type captureWriter struct {
+	messages []log.LogMessage
+}
+
+func (c *captureWriter) WriteMessage(ctx context.Context, l *log.LogMessage) error {
+	c.messages = append(c.messages, *l)
+	return nil
+}
+
+type payment struct {
+	CardNumber string `json:"cardNumber" log:"mask"`
+	CVV        string `json:"securityCode" log:"redact"`
+	Customer   struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"customer"`
+	Notes []string `json:"notes"`
+}
+
+func TestRedaction(t *testing.T) {
+	writer := &captureWriter{}
+	logger := log.NewLogger(context.TODO(), &log.Config{ServiceName: "test", LogLevel: int(log.DEBUG), RedactPaths: []string{"$.meta.internal[*].ref"}, RedactPatterns: true}, "RedactTest", log.NewDefaultLogMux(writer), nil)
+	p := payment{CardNumber: "4111111111111111", CVV: "123", Notes: []string{"PAN ABCPE1234F", "aadhaar 2341 2341 2346", "call +91 9876543210"}}
+	p.Customer.Name = "Jane"
+	p.Customer.Email = "jane.doe@example.com"
+	logger.Info(context.Background(), "Payment", p)
+	logger.Info(context.Background(), "Request", map[string]any{
+		"Password":  "hunter2",
+		"x-api-key": "abc",
+		"meta":      map[string]any{"internal": []any{map[string]any{"ref": "r-1", "id": 1}}},
+		"card":      4111111111111111,
+	})
+	logger.Debug(context.Background(), "Card 4111-1111-1111-1111 used", "correlation go-base-1d2c3b4a-1111-2222-3333-234123412346")
+
+	var res map[string]any
+	assert.NilError(t, json.Unmarshal(writer.messages[0].FullMessageJSON, &res))
+	assert.Equal(t, res["cardNumber"], "************1111")
+	assert.Equal(t, res["securityCode"], log.RedactedValue)
+	assert.DeepEqual(t, res["customer"], map[string]any{"name": "Jane", "email": "j*******@example.com"})
+	assert.DeepEqual(t, res["notes"], []any{"PAN ******234F", "aadhaar **********2346", "call **********3210"})
+
+	assert.NilError(t, json.Unmarshal(writer.messages[1].FullMessageJSON, &res))
+	assert.Equal(t, res["Password"], log.RedactedValue)
+	assert.Equal(t, res["x-api-key"], log.RedactedValue)
+	assert.Equal(t, res["card"], float64(4111111111111111))
+	assert.DeepEqual(t, res["meta"], map[string]any{"internal": []any{map[string]any{"ref": log.RedactedValue, "id": float64(1)}}})
+
+	assert.Equal(t, writer.messages[2].ShortMessage, "Card ***************1111 used")
+	assert.Equal(t, writer.messages[2].FullMessage, "correlation go-base-1d2c3b4a-1111-2222-3333-234123412346")
+}
+
+func TestRedactionDisabled(t *testing.T) {
+	writer := &captureWriter{}
+	logger := log.NewLogger(context.TODO(), &log.Config{ServiceName: "test", LogLevel: int(log.DEBUG), DisableRedaction: true}, "RedactTest", log.NewDefaultLogMux(writer), nil)
+	logger.Info(context.Background(), "Request", map[string]any{"password": "hunter2"})
+	assert.Equal(t, string(writer.messages[0].FullMessageJSON), `{"password":"hunter2"}`)
+}
+
+func TestRedactionDefaultsAreKeyBased(t *testing.T) {
+	writer := &captureWriter{}
+	logger := log.NewLogger(context.TODO(), &log.Config{ServiceName: "test", LogLevel: int(log.DEBUG)}, "RedactTest", log.NewDefaultLogMux(writer), nil)
+	logger.Info(context.Background(), "Request", map[string]any{"password": "hunter2", "note": "mail jane.doe@example.com"})
+	assert.Equal(t, string(writer.messages[0].FullMessageJSON), `{"note":"mail jane.doe@example.com","password":"***REDACTED***"}`)
+}
+
+func TestRedactionIgnoresTimestampsAndIDs(t *testing.T) {
+	r := log.NewRedactor(nil, nil, log.DefaultDetectors)
+	for _, val := range []string{"1697040000000", "1697040000000000000", "9876543210", "234123412346", "order 5000000000000000001"} {
+		assert.Equal(t, r.RedactString(val), val)
+	}
+	assert.Equal(t, r.RedactString("card 4111111111111111"), "card ************1111")
+	blob, err := r.RedactJSON([]byte(`{"ts":1697040000000,"id":4111111111111111}`))
+	assert.NilError(t, err)
+	assert.Equal(t, string(blob), `{"id":4111111111111111,"ts":1697040000000}`)
+}
+
+func TestRedactFieldsPreservesTypes(t *testing.T) {
+	r := log.NewRedactor([]string{"password"}, nil, log.DefaultDetectors)
+	nested := map[string]any{"id": 7}
+	fields := r.RedactFields(map[string]any{"count": 3, "ratio": 0.5, "ok": true, "name": "x", "nested": nested, "password": "p"})
+	assert.Equal(t, fields["count"], 3)
+	assert.Equal(t, fields["ratio"], 0.5)
+	assert.Equal(t, fields["ok"], true)
+	assert.Equal(t, fields["name"], "x")
+	assert.DeepEqual(t, fields["nested"], nested)
+	assert.Equal(t, fields["password"], log.RedactedValue)
+	fields = r.RedactFields(map[string]any{"user": map[string]any{"password": "p", "age": 30}})
+	assert.DeepEqual(t, fields["user"], json.RawMessage(`{"age":30,"password":"***REDACTED***"}`))
+}
@@ -0,0 +1,117 @@
+package log
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+var DefaultSamplingInterval = time.Second
+
+type SamplingConfig struct {
+	Initial    int
+	Thereafter int
+	Interval   time.Duration
+}
+
+type SampledCount struct {
+	Level        string `json:"level"`
+	Module       string `json:"module"`
+	ShortMessage string `json:"shortMessage"`
+	Suppressed   int    `json:"suppressed"`
+}
+
+type samplerKey struct {
+	level        LogLevelCode
+	module       string
+	shortMessage string
+}
+
+type Sampler struct {
+	config      SamplingConfig
+	lock        sync.Mutex
+	windowStart time.Time
+	counts      map[samplerKey]int
+	suppressed  map[samplerKey]int
+	stop        chan struct{}
+	stopOnce    sync.Once
+}
+
+func NewSampler(config SamplingConfig) *Sampler {
+	if config.Interval <= 0 {
+		config.Interval = DefaultSamplingInterval
+	}
+	return &Sampler{
+		config:     config,
+		counts:     make(map[samplerKey]int),
+		suppressed: make(map[samplerKey]int),
+		stop:       make(chan struct{}),
+	}
+}
+
+// Run emits the suppressed counts every interval so they are not lost when traffic stops, until Stop is called
+func (s *Sampler) Run(emit func([]SampledCount)) {
+	go func() {
+		ticker := time.NewTicker(s.config.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if summary := s.Flush(); len(summary) > 0 {
+					emit(summary)
+				}
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends Run and returns the counts suppressed since the last summary
+func (s *Sampler) Stop() []SampledCount {
+	s.stopOnce.Do(func() { close(s.stop) })
+	return s.Flush()
+}
+
+func (s *Sampler) Flush() []SampledCount {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.windowStart = time.Now()
+	s.counts = make(map[samplerKey]int)
+	return s.flush()
+}
+
+func (s *Sampler) Sample(level LogLevelCode, module, shortMessage string) (allow bool, summary []SampledCount) {
+	if level <= ERROR {
+		return true, nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := time.Now()
+	if now.Sub(s.windowStart) >= s.config.Interval {
+		summary = s.flush()
+		s.windowStart = now
+		s.counts = make(map[samplerKey]int)
+	}
+	key := samplerKey{level: level, module: module, shortMessage: shortMessage}
+	s.counts[key]++
+	n := s.counts[key]
+	if n <= s.config.Initial || (s.config.Thereafter > 0 && (n-s.config.Initial)%s.config.Thereafter == 0) {
+		return true, summary
+	}
+	s.suppressed[key]++
+	return false, summary
+}
+
+func (s *Sampler) flush() []SampledCount {
+	if len(s.suppressed) == 0 {
+		return nil
+	}
+	summary := make([]SampledCount, 0, len(s.suppressed))
+	for key, count := range s.suppressed {
+		summary = append(summary, SampledCount{Level: GetLogLevelMap(key.level).LogLevelName, Module: key.module, ShortMessage: key.shortMessage, Suppressed: count})
+	}
+	sort.Slice(summary, func(i, j int) bool { return summary[i].Suppressed > summary[j].Suppressed })
+	s.suppressed = make(map[samplerKey]int)
+	return summary
+}
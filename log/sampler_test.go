@@ -0,0 +1,52 @@
+package log_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sabariramc/goserverbase/log"
+	"gotest.tools/assert"
+)
+
+func TestSampler(t *testing.T) {
+	lMux := &recordingMux{}
+	logger := log.NewLogger(context.TODO(), &log.Config{ServiceName: "test", LogLevel: int(log.DEBUG), Sampling: &log.SamplingConfig{Initial: 2, Thereafter: 3, Interval: time.Millisecond * 200}}, "app", lMux, nil)
+	for i := 0; i < 10; i++ {
+		logger.Info(context.Background(), "Polling result", i)
+		logger.Error(context.Background(), "Failure", i)
+	}
+	infoCount, errorCount := 0, 0
+	for _, msg := range lMux.getMessages() {
+		switch msg {
+		case "app:Polling result":
+			infoCount++
+		case "app:Failure":
+			errorCount++
+		}
+	}
+	assert.Equal(t, infoCount, 4)
+	assert.Equal(t, errorCount, 10)
+	lMux.reset()
+	time.Sleep(time.Millisecond * 250)
+	assert.DeepEqual(t, lMux.getMessages(), []string{"app:Log sampling summary"})
+	lMux.reset()
+	logger.Info(context.Background(), "Polling result", nil)
+	logger.Info(context.Background(), "Polling result", nil)
+	logger.Info(context.Background(), "Polling result", nil)
+	assert.NilError(t, logger.Shutdown(context.Background()))
+	assert.DeepEqual(t, lMux.getMessages(), []string{"app:Polling result", "app:Polling result", "app:Log sampling summary"})
+}
+
+func TestSamplerSummary(t *testing.T) {
+	sampler := log.NewSampler(log.SamplingConfig{Initial: 1, Interval: time.Millisecond * 100})
+	for i := 0; i < 5; i++ {
+		allow, summary := sampler.Sample(log.DEBUG, "kafka", "Polling result")
+		assert.Equal(t, allow, i == 0)
+		assert.Equal(t, len(summary), 0)
+	}
+	time.Sleep(time.Millisecond * 150)
+	allow, summary := sampler.Sample(log.INFO, "kafka", "Other")
+	assert.Assert(t, allow)
+	assert.DeepEqual(t, summary, []log.SampledCount{{Level: "DEBUG", Module: "kafka", ShortMessage: "Polling result", Suppressed: 4}})
+}
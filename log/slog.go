@@ -0,0 +1,156 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+)
+
+type SlogHandler struct {
+	logger *Logger
+	fields map[string]any
+	groups []string
+}
+
+func NewSlogHandler(logger *Logger) *SlogHandler {
+	return &SlogHandler{logger: logger, fields: map[string]any{}}
+}
+
+func NewSlogLogger(logger *Logger) *slog.Logger {
+	return slog.New(NewSlogHandler(logger))
+}
+
+func SlogLevelToLogLevel(level slog.Level) LogLevelCode {
+	switch {
+	case level < slog.LevelInfo:
+		return DEBUG
+	case level < slog.LevelInfo+2:
+		return INFO
+	case level < slog.LevelWarn:
+		return NOTICE
+	case level < slog.LevelError:
+		return WARNING
+	case level < slog.LevelError+4:
+		return ERROR
+	default:
+		return CRITICAL
+	}
+}
+
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.logger.levelEnabled(SlogLevelToLogLevel(level))
+}
+
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	fields := cloneFields(h.fields)
+	target := groupFields(fields, h.groups)
+	r.Attrs(func(attr slog.Attr) bool {
+		addAttr(target, attr)
+		return true
+	})
+	var fullMessage interface{}
+	if len(fields) > 0 {
+		fullMessage = fields
+	}
+	level := logLevelMap[SlogLevelToLogLevel(r.Level)]
+	if !h.logger.levelEnabled(level.Level) {
+		return nil
+	}
+	caller := ""
+	if h.logger.config.EnableCaller {
+		caller = getCallerFromPC(r.PC)
+	}
+	h.logger.sample(ctx, level, r.Message, fullMessage, caller)
+	return nil
+}
+
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	fields := cloneFields(h.fields)
+	target := groupFields(fields, h.groups)
+	for _, attr := range attrs {
+		addAttr(target, attr)
+	}
+	return &SlogHandler{logger: h.logger, fields: fields, groups: h.groups}
+}
+
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	groups := append(append([]string{}, h.groups...), name)
+	return &SlogHandler{logger: h.logger, fields: h.fields, groups: groups}
+}
+
+func (l *Logger) LogAttrs(ctx context.Context, level LogLevelCode, shortMessage string, attrs ...slog.Attr) {
+	logLevel, ok := logLevelMap[level]
+	if !ok {
+		logLevel = logLevelMap[INFO]
+	}
+	var fullMessage interface{}
+	if len(attrs) > 0 {
+		fullMessage = AttrsToMap(attrs...)
+	}
+	l.print(ctx, logLevel, shortMessage, fullMessage)
+}
+
+func AttrsToMap(attrs ...slog.Attr) map[string]any {
+	fields := make(map[string]any, len(attrs))
+	for _, attr := range attrs {
+		addAttr(fields, attr)
+	}
+	return fields
+}
+
+func addAttr(fields map[string]any, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+	switch attr.Value.Kind() {
+	case slog.KindGroup:
+		group := attr.Value.Group()
+		if len(group) == 0 {
+			return
+		}
+		target := fields
+		if attr.Key != "" {
+			target = groupFields(fields, []string{attr.Key})
+		}
+		for _, item := range group {
+			addAttr(target, item)
+		}
+	case slog.KindDuration:
+		fields[attr.Key] = attr.Value.Duration().String()
+	default:
+		val := attr.Value.Any()
+		if err, ok := val.(error); ok {
+			val = err.Error()
+		}
+		fields[attr.Key] = val
+	}
+}
+
+func groupFields(fields map[string]any, groups []string) map[string]any {
+	for _, group := range groups {
+		child, ok := fields[group].(map[string]any)
+		if !ok {
+			child = map[string]any{}
+			fields[group] = child
+		}
+		fields = child
+	}
+	return fields
+}
+
+func cloneFields(fields map[string]any) map[string]any {
+	res := make(map[string]any, len(fields))
+	for key, val := range fields {
+		if child, ok := val.(map[string]any); ok {
+			val = cloneFields(child)
+		}
+		res[key] = val
+	}
+	return res
+}
@@ -0,0 +1,47 @@
+package log_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/sabariramc/goserverbase/log"
+	"gotest.tools/assert"
+)
+
+func TestSlogHandler(t *testing.T) {
+	writer := &captureWriter{}
+	logger := log.NewLogger(context.TODO(), &log.Config{ServiceName: "test", LogLevel: int(log.INFO)}, "SlogTest", log.NewDefaultLogMux(writer), nil)
+	sLogger := log.NewSlogLogger(logger).With("component", "worker").WithGroup("request")
+	sLogger.Debug("hidden")
+	sLogger.Warn("slow request", "latency", time.Second, slog.Group("user", "id", 42), "err", errors.New("timeout"))
+	assert.Equal(t, len(writer.messages), 1)
+	msg := writer.messages[0]
+	assert.Equal(t, msg.ShortMessage, "slow request")
+	assert.Equal(t, msg.Level, log.WARNING)
+	assert.Equal(t, msg.ModuleName, "SlogTest")
+	var fields map[string]any
+	assert.NilError(t, json.Unmarshal(msg.FullMessageJSON, &fields))
+	assert.DeepEqual(t, fields, map[string]any{
+		"component": "worker",
+		"request": map[string]any{
+			"latency": "1s",
+			"user":    map[string]any{"id": float64(42)},
+			"err":     "timeout",
+		},
+	})
+}
+
+func TestLogAttrs(t *testing.T) {
+	writer := &captureWriter{}
+	logger := log.NewLogger(context.TODO(), &log.Config{ServiceName: "test", LogLevel: int(log.INFO)}, "SlogTest", log.NewDefaultLogMux(writer), nil)
+	logger.LogAttrs(context.Background(), log.NOTICE, "order placed", slog.String("orderId", "o-1"), slog.Int("items", 3))
+	logger.Info(context.Background(), "order shipped", slog.String("orderId", "o-1"))
+	assert.Equal(t, len(writer.messages), 2)
+	assert.Equal(t, writer.messages[0].Level, log.NOTICE)
+	assert.Equal(t, string(writer.messages[0].FullMessageJSON), `{"items":3,"orderId":"o-1"}`)
+	assert.Equal(t, string(writer.messages[1].FullMessageJSON), `{"orderId":"o-1"}`)
+}
@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+)
+
+type Counter struct {
+	value atomicFloat
+}
+
+func (c *Counter) Inc() {
+	c.value.Add(1)
+}
+
+func (c *Counter) Add(val float64) {
+	if val < 0 {
+		panic(fmt.Errorf("metrics.Counter: counter cannot decrease, got %v", val))
+	}
+	c.value.Add(val)
+}
+
+func (c *Counter) Value() float64 {
+	return c.value.Load()
+}
+
+type CounterVec struct {
+	*metricVec[Counter]
+}
+
+func NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	return &CounterVec{metricVec: newMetricVec(name, help, "counter", labelNames, func() *Counter { return &Counter{} })}
+}
+
+func (c *CounterVec) WithLabelValues(labelValues ...string) *Counter {
+	return c.withLabelValues(labelValues...)
+}
+
+func (c *CounterVec) WriteText(w io.Writer) {
+	c.writeText(w, func(w io.Writer, name string, labelNames, labelValues []string, m *Counter) {
+		fmt.Fprintf(w, "%v%v %v\n", name, formatLabels(labelNames, labelValues, "", ""), formatFloat(m.Value()))
+	})
+}
+
+func NewCounter(name, help string) *CounterVec {
+	c := NewCounterVec(name, help)
+	c.WithLabelValues()
+	return c
+}
+
+type Gauge struct {
+	value atomicFloat
+}
+
+func (g *Gauge) Set(val float64) {
+	g.value.Set(val)
+}
+
+func (g *Gauge) Add(val float64) {
+	g.value.Add(val)
+}
+
+func (g *Gauge) Inc() {
+	g.value.Add(1)
+}
+
+func (g *Gauge) Dec() {
+	g.value.Add(-1)
+}
+
+func (g *Gauge) Value() float64 {
+	return g.value.Load()
+}
+
+type GaugeVec struct {
+	*metricVec[Gauge]
+}
+
+func NewGaugeVec(name, help string, labelNames ...string) *GaugeVec {
+	return &GaugeVec{metricVec: newMetricVec(name, help, "gauge", labelNames, func() *Gauge { return &Gauge{} })}
+}
+
+func (g *GaugeVec) WithLabelValues(labelValues ...string) *Gauge {
+	return g.withLabelValues(labelValues...)
+}
+
+func (g *GaugeVec) WriteText(w io.Writer) {
+	g.writeText(w, func(w io.Writer, name string, labelNames, labelValues []string, m *Gauge) {
+		fmt.Fprintf(w, "%v%v %v\n", name, formatLabels(labelNames, labelValues, "", ""), formatFloat(m.Value()))
+	})
+}
+
+func NewGauge(name, help string) *GaugeVec {
+	g := NewGaugeVec(name, help)
+	g.WithLabelValues()
+	return g
+}
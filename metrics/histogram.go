@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type Histogram struct {
+	upperBounds []float64
+	counts      []atomic.Uint64
+	count       atomic.Uint64
+	sum         atomicFloat
+}
+
+func newHistogram(buckets []float64) *Histogram {
+	return &Histogram{upperBounds: buckets, counts: make([]atomic.Uint64, len(buckets))}
+}
+
+func (h *Histogram) Observe(val float64) {
+	idx := sort.SearchFloat64s(h.upperBounds, val)
+	if idx < len(h.counts) {
+		h.counts[idx].Add(1)
+	}
+	h.count.Add(1)
+	h.sum.Add(val)
+}
+
+func (h *Histogram) ObserveDuration(start time.Time) {
+	h.Observe(time.Since(start).Seconds())
+}
+
+func (h *Histogram) Count() uint64 {
+	return h.count.Load()
+}
+
+type HistogramVec struct {
+	*metricVec[Histogram]
+}
+
+func NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	sorted := make([]float64, len(buckets))
+	copy(sorted, buckets)
+	sort.Float64s(sorted)
+	return &HistogramVec{metricVec: newMetricVec(name, help, "histogram", labelNames, func() *Histogram { return newHistogram(sorted) })}
+}
+
+func (h *HistogramVec) WithLabelValues(labelValues ...string) *Histogram {
+	return h.withLabelValues(labelValues...)
+}
+
+func (h *HistogramVec) WriteText(w io.Writer) {
+	h.writeText(w, func(w io.Writer, name string, labelNames, labelValues []string, m *Histogram) {
+		var cumulative uint64
+		for i, bound := range m.upperBounds {
+			cumulative += m.counts[i].Load()
+			fmt.Fprintf(w, "%v_bucket%v %v\n", name, formatLabels(labelNames, labelValues, "le", formatFloat(bound)), cumulative)
+		}
+		count := m.count.Load()
+		fmt.Fprintf(w, "%v_bucket%v %v\n", name, formatLabels(labelNames, labelValues, "le", "+Inf"), count)
+		fmt.Fprintf(w, "%v_sum%v %v\n", name, formatLabels(labelNames, labelValues, "", ""), formatFloat(m.sum.Load()))
+		fmt.Fprintf(w, "%v_count%v %v\n", name, formatLabels(labelNames, labelValues, "", ""), count)
+	})
+}
+
+func NewHistogram(name, help string, buckets []float64) *HistogramVec {
+	h := NewHistogramVec(name, help, buckets)
+	h.WithLabelValues()
+	return h
+}
@@ -0,0 +1,142 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type atomicFloat struct {
+	bits atomic.Uint64
+}
+
+func (f *atomicFloat) Add(val float64) {
+	for {
+		old := f.bits.Load()
+		if f.bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+val)) {
+			return
+		}
+	}
+}
+
+func (f *atomicFloat) Set(val float64) {
+	f.bits.Store(math.Float64bits(val))
+}
+
+func (f *atomicFloat) Load() float64 {
+	return math.Float64frombits(f.bits.Load())
+}
+
+type metricVec[T any] struct {
+	name       string
+	help       string
+	metricType string
+	labelNames []string
+	lock       sync.RWMutex
+	series     map[string]*series[T]
+	newMetric  func() *T
+}
+
+type series[T any] struct {
+	labelValues []string
+	metric      *T
+}
+
+func newMetricVec[T any](name, help, metricType string, labelNames []string, newMetric func() *T) *metricVec[T] {
+	return &metricVec[T]{
+		name:       name,
+		help:       help,
+		metricType: metricType,
+		labelNames: labelNames,
+		series:     make(map[string]*series[T]),
+		newMetric:  newMetric,
+	}
+}
+
+func (v *metricVec[T]) Name() string {
+	return v.name
+}
+
+func (v *metricVec[T]) withLabelValues(labelValues ...string) *T {
+	if len(labelValues) != len(v.labelNames) {
+		panic(fmt.Errorf("metrics.%v: expected %v label values got %v", v.name, len(v.labelNames), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+	v.lock.RLock()
+	s, ok := v.series[key]
+	v.lock.RUnlock()
+	if ok {
+		return s.metric
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if s, ok = v.series[key]; ok {
+		return s.metric
+	}
+	values := make([]string, len(labelValues))
+	copy(values, labelValues)
+	s = &series[T]{labelValues: values, metric: v.newMetric()}
+	v.series[key] = s
+	return s.metric
+}
+
+func (v *metricVec[T]) writeText(w io.Writer, writeSeries func(io.Writer, string, []string, []string, *T)) {
+	fmt.Fprintf(w, "# HELP %v %v\n", v.name, escapeHelp(v.help))
+	fmt.Fprintf(w, "# TYPE %v %v\n", v.name, v.metricType)
+	v.lock.RLock()
+	keys := make([]string, 0, len(v.series))
+	for key := range v.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	seriesList := make([]*series[T], len(keys))
+	for i, key := range keys {
+		seriesList[i] = v.series[key]
+	}
+	v.lock.RUnlock()
+	for _, s := range seriesList {
+		writeSeries(w, v.name, v.labelNames, s.labelValues, s.metric)
+	}
+}
+
+func formatLabels(names, values []string, extraName, extraValue string) string {
+	if len(names) == 0 && extraName == "" {
+		return ""
+	}
+	pairs := make([]string, 0, len(names)+1)
+	for i, name := range names {
+		pairs = append(pairs, name+`="`+escapeLabelValue(values[i])+`"`)
+	}
+	if extraName != "" {
+		pairs = append(pairs, extraName+`="`+escapeLabelValue(extraValue)+`"`)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escapeLabelValue(val string) string {
+	return labelValueEscaper.Replace(val)
+}
+
+func escapeHelp(val string) string {
+	return helpEscaper.Replace(val)
+}
+
+func formatFloat(val float64) string {
+	switch {
+	case math.IsInf(val, 1):
+		return "+Inf"
+	case math.IsInf(val, -1):
+		return "-Inf"
+	case math.IsNaN(val):
+		return "NaN"
+	}
+	return strconv.FormatFloat(val, 'g', -1, 64)
+}
@@ -0,0 +1,59 @@
+package metrics_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sabariramc/goserverbase/metrics"
+	"gotest.tools/assert"
+)
+
+func TestRegistryTextFormat(t *testing.T) {
+	r := metrics.NewRegistry()
+	counter := metrics.NewCounterVec("orders_total", "Orders created", "status")
+	gauge := metrics.NewGauge("queue_depth", "Pending jobs")
+	histogram := metrics.NewHistogramVec("job_duration_seconds", "Job duration", []float64{1, 0.1}, "job")
+	r.MustRegister(counter, gauge, histogram)
+	counter.WithLabelValues("ok").Inc()
+	counter.WithLabelValues("ok").Add(2)
+	counter.WithLabelValues(`fa"il`).Inc()
+	gauge.WithLabelValues().Set(4)
+	gauge.WithLabelValues().Dec()
+	histogram.WithLabelValues("sync").Observe(0.05)
+	histogram.WithLabelValues("sync").Observe(0.5)
+	histogram.WithLabelValues("sync").Observe(5)
+	var buf bytes.Buffer
+	r.WriteText(&buf)
+	expected := `# HELP job_duration_seconds Job duration
+# TYPE job_duration_seconds histogram
+job_duration_seconds_bucket{job="sync",le="0.1"} 1
+job_duration_seconds_bucket{job="sync",le="1"} 2
+job_duration_seconds_bucket{job="sync",le="+Inf"} 3
+job_duration_seconds_sum{job="sync"} 5.55
+job_duration_seconds_count{job="sync"} 3
+# HELP orders_total Orders created
+# TYPE orders_total counter
+orders_total{status="fa\"il"} 1
+orders_total{status="ok"} 3
+# HELP queue_depth Pending jobs
+# TYPE queue_depth gauge
+queue_depth 3
+`
+	assert.Equal(t, buf.String(), expected)
+	err := r.Register(metrics.NewCounter("orders_total", "dup"))
+	assert.Assert(t, errors.Is(err, metrics.ErrDuplicateMetric))
+	err = r.Register(metrics.NewCounter("orders-total", "invalid"))
+	assert.Assert(t, errors.Is(err, metrics.ErrInvalidMetricName))
+}
+
+func TestRegistryHandler(t *testing.T) {
+	r := metrics.NewRegistry()
+	r.MustRegister(metrics.NewCounter("hits_total", "Hits"))
+	w := httptest.NewRecorder()
+	r.Handler()(w, httptest.NewRequest(http.MethodGet, "/meta/metrics", nil))
+	assert.Equal(t, w.Result().Header.Get("Content-Type"), metrics.ContentTypeText)
+	assert.Equal(t, w.Body.String(), "# HELP hits_total Hits\n# TYPE hits_total counter\nhits_total 0\n")
+}
@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+const ContentTypeText = "text/plain; version=0.0.4; charset=utf-8"
+
+var ErrDuplicateMetric = fmt.Errorf("duplicate metric name")
+var ErrInvalidMetricName = fmt.Errorf("invalid metric name")
+
+var metricNamePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+type Collector interface {
+	Name() string
+	WriteText(w io.Writer)
+}
+
+type Registry struct {
+	lock       sync.RWMutex
+	collectors map[string]Collector
+}
+
+var DefaultRegistry = NewRegistry()
+
+func NewRegistry() *Registry {
+	return &Registry{collectors: make(map[string]Collector)}
+}
+
+func (r *Registry) Register(c Collector) error {
+	if !metricNamePattern.MatchString(c.Name()) {
+		return fmt.Errorf("Registry.Register: %w: %v", ErrInvalidMetricName, c.Name())
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.collectors[c.Name()]; ok {
+		return fmt.Errorf("Registry.Register: %w: %v", ErrDuplicateMetric, c.Name())
+	}
+	r.collectors[c.Name()] = c
+	return nil
+}
+
+func (r *Registry) MustRegister(collectors ...Collector) {
+	for _, c := range collectors {
+		if err := r.Register(c); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (r *Registry) Unregister(name string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.collectors, name)
+}
+
+func (r *Registry) WriteText(w io.Writer) {
+	r.lock.RLock()
+	names := make([]string, 0, len(r.collectors))
+	for name := range r.collectors {
+		names = append(names, name)
+	}
+	collectors := make([]Collector, len(names))
+	sort.Strings(names)
+	for i, name := range names {
+		collectors[i] = r.collectors[name]
+	}
+	r.lock.RUnlock()
+	for _, c := range collectors {
+		c.WriteText(w)
+	}
+}
+
+func (r *Registry) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		var buf bytes.Buffer
+		r.WriteText(&buf)
+		w.Header().Set("Content-Type", ContentTypeText)
+		w.WriteHeader(http.StatusOK)
+		w.Write(buf.Bytes())
+	}
+}
+
+func Register(c Collector) error {
+	return DefaultRegistry.Register(c)
+}
+
+func MustRegister(collectors ...Collector) {
+	DefaultRegistry.MustRegister(collectors...)
+}
+
+func Handler() http.HandlerFunc {
+	return DefaultRegistry.Handler()
+}
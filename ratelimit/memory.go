@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const memorySweepInterval = 1024
+
+type memoryCounter struct {
+	count    int64
+	expireAt time.Time
+}
+
+type memoryBucket struct {
+	tokens   float64
+	last     time.Time
+	expireAt time.Time
+}
+
+type MemoryStore struct {
+	lock     sync.Mutex
+	counters map[string]*memoryCounter
+	buckets  map[string]*memoryBucket
+	ops      int
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{counters: make(map[string]*memoryCounter), buckets: make(map[string]*memoryBucket)}
+}
+
+func (m *MemoryStore) Increment(ctx context.Context, key string, expireAt time.Time) (int64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	now := time.Now()
+	m.sweep(now)
+	counter, ok := m.counters[key]
+	if !ok || now.After(counter.expireAt) {
+		counter = &memoryCounter{expireAt: expireAt}
+		m.counters[key] = counter
+	}
+	counter.count++
+	return counter.count, nil
+}
+
+func (m *MemoryStore) Count(ctx context.Context, key string) (int64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	counter, ok := m.counters[key]
+	if !ok || time.Now().After(counter.expireAt) {
+		return 0, nil
+	}
+	return counter.count, nil
+}
+
+func (m *MemoryStore) Take(ctx context.Context, key string, capacity int, refill time.Duration, now time.Time) (int, time.Duration, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.sweep(now)
+	bucket, ok := m.buckets[key]
+	if !ok {
+		bucket = &memoryBucket{tokens: float64(capacity), last: now}
+		m.buckets[key] = bucket
+	}
+	if elapsed := now.Sub(bucket.last); elapsed > 0 {
+		bucket.tokens += float64(elapsed) / float64(refill)
+		if bucket.tokens > float64(capacity) {
+			bucket.tokens = float64(capacity)
+		}
+		bucket.last = now
+	}
+	bucket.expireAt = now.Add(refill * time.Duration(capacity))
+	if bucket.tokens < 1 {
+		return 0, time.Duration((1 - bucket.tokens) * float64(refill)), nil
+	}
+	bucket.tokens--
+	return int(bucket.tokens), 0, nil
+}
+
+func (m *MemoryStore) sweep(now time.Time) {
+	m.ops++
+	if m.ops%memorySweepInterval != 0 {
+		return
+	}
+	for key, counter := range m.counters {
+		if now.After(counter.expireAt) {
+			delete(m.counters, key)
+		}
+	}
+	for key, bucket := range m.buckets {
+		if now.After(bucket.expireAt) {
+			delete(m.buckets, key)
+		}
+	}
+}
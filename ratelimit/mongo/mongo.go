@@ -0,0 +1,115 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type counter struct {
+	Key      string    `bson:"_id"`
+	Count    int64     `bson:"count"`
+	ExpireAt time.Time `bson:"expireAt"`
+}
+
+type bucket struct {
+	Key      string    `bson:"_id"`
+	Tokens   float64   `bson:"tokens"`
+	Last     time.Time `bson:"last"`
+	Allowed  bool      `bson:"allowed"`
+	ExpireAt time.Time `bson:"expireAt"`
+}
+
+type Store struct {
+	collection *mongo.Collection
+}
+
+func New(ctx context.Context, collection *mongo.Collection) (*Store, error) {
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expireAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("mongo.New: %w", err)
+	}
+	return &Store{collection: collection}, nil
+}
+
+func (s *Store) Increment(ctx context.Context, key string, expireAt time.Time) (int64, error) {
+	res, err := s.increment(ctx, key, expireAt)
+	if mongo.IsDuplicateKeyError(err) {
+		res, err = s.increment(ctx, key, expireAt)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("Store.Increment: %w", err)
+	}
+	return res.Count, nil
+}
+
+func (s *Store) increment(ctx context.Context, key string, expireAt time.Time) (res counter, err error) {
+	err = s.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": key},
+		bson.M{"$inc": bson.M{"count": 1}, "$setOnInsert": bson.M{"expireAt": expireAt}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&res)
+	return
+}
+
+func (s *Store) Count(ctx context.Context, key string) (int64, error) {
+	var res counter
+	err := s.collection.FindOne(ctx, bson.M{"_id": key}).Decode(&res)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("Store.Count: %w", err)
+	}
+	if time.Now().After(res.ExpireAt) {
+		return 0, nil
+	}
+	return res.Count, nil
+}
+
+// Take refills and takes a token in a single update pipeline so concurrent instances cannot overdraw a bucket
+func (s *Store) Take(ctx context.Context, key string, capacity int, refill time.Duration, now time.Time) (int, time.Duration, error) {
+	res, err := s.take(ctx, key, capacity, refill, now)
+	if mongo.IsDuplicateKeyError(err) {
+		res, err = s.take(ctx, key, capacity, refill, now)
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("Store.Take: %w", err)
+	}
+	if !res.Allowed {
+		return 0, time.Duration((1 - res.Tokens) * float64(refill)), nil
+	}
+	return int(res.Tokens), 0, nil
+}
+
+func (s *Store) take(ctx context.Context, key string, capacity int, refill time.Duration, now time.Time) (res bucket, err error) {
+	refillMs := float64(refill) / float64(time.Millisecond)
+	elapsedMs := bson.M{"$max": bson.A{0, bson.M{"$subtract": bson.A{now, bson.M{"$ifNull": bson.A{"$last", now}}}}}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"tokens": bson.M{"$min": bson.A{capacity, bson.M{"$add": bson.A{
+				bson.M{"$ifNull": bson.A{"$tokens", capacity}},
+				bson.M{"$divide": bson.A{elapsedMs, refillMs}},
+			}}}},
+			"last": bson.M{"$max": bson.A{bson.M{"$ifNull": bson.A{"$last", now}}, now}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"allowed":  bson.M{"$gte": bson.A{"$tokens", 1}},
+			"tokens":   bson.M{"$cond": bson.A{bson.M{"$gte": bson.A{"$tokens", 1}}, bson.M{"$subtract": bson.A{"$tokens", 1}}, "$tokens"}},
+			"expireAt": now.Add(refill * time.Duration(capacity)),
+		}}},
+	}
+	err = s.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": key},
+		pipeline,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&res)
+	return
+}
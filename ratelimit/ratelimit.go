@@ -0,0 +1,236 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sabariramc/goserverbase/log"
+)
+
+type Algorithm int
+
+const (
+	TokenBucket Algorithm = iota
+	SlidingWindow
+)
+
+type WindowStore interface {
+	Increment(ctx context.Context, key string, expireAt time.Time) (int64, error)
+	Count(ctx context.Context, key string) (int64, error)
+}
+
+type BucketStore interface {
+	Take(ctx context.Context, key string, capacity int, refill time.Duration, now time.Time) (remaining int, wait time.Duration, err error)
+}
+
+type KeyFunc func(r *http.Request) (string, bool)
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+type Limiter struct {
+	name        string
+	algorithm   Algorithm
+	limit       int
+	window      time.Duration
+	key         KeyFunc
+	windowStore WindowStore
+	bucketStore BucketStore
+}
+
+var ErrInvalidLimit = fmt.Errorf("invalid rate limit")
+
+func NewTokenBucketLimiter(name string, limit int, window time.Duration, key KeyFunc, store BucketStore) (*Limiter, error) {
+	if err := validateLimit(limit, window, key); err != nil {
+		return nil, fmt.Errorf("ratelimit.NewTokenBucketLimiter: %v: %w", name, err)
+	}
+	if store == nil {
+		return nil, fmt.Errorf("ratelimit.NewTokenBucketLimiter: %v: %w: store is nil", name, ErrInvalidLimit)
+	}
+	return &Limiter{name: name, algorithm: TokenBucket, limit: limit, window: window, key: key, bucketStore: store}, nil
+}
+
+func NewSlidingWindowLimiter(name string, limit int, window time.Duration, key KeyFunc, store WindowStore) (*Limiter, error) {
+	if err := validateLimit(limit, window, key); err != nil {
+		return nil, fmt.Errorf("ratelimit.NewSlidingWindowLimiter: %v: %w", name, err)
+	}
+	if store == nil {
+		return nil, fmt.Errorf("ratelimit.NewSlidingWindowLimiter: %v: %w: store is nil", name, ErrInvalidLimit)
+	}
+	return &Limiter{name: name, algorithm: SlidingWindow, limit: limit, window: window, key: key, windowStore: store}, nil
+}
+
+func validateLimit(limit int, window time.Duration, key KeyFunc) error {
+	if limit <= 0 {
+		return fmt.Errorf("%w: limit should be positive, got %v", ErrInvalidLimit, limit)
+	}
+	if window < time.Duration(limit) {
+		return fmt.Errorf("%w: window %v is too short for limit %v", ErrInvalidLimit, window, limit)
+	}
+	if key == nil {
+		return fmt.Errorf("%w: key func is nil", ErrInvalidLimit)
+	}
+	return nil
+}
+
+func (l *Limiter) Name() string {
+	return l.name
+}
+
+func (l *Limiter) Key(r *http.Request) (string, bool) {
+	key, ok := l.key(r)
+	if !ok || key == "" {
+		return "", false
+	}
+	return l.name + ":" + key, true
+}
+
+func (l *Limiter) Allow(ctx context.Context, key string) (Result, error) {
+	now := time.Now()
+	if l.algorithm == SlidingWindow {
+		return l.allowWindow(ctx, key, now)
+	}
+	refill := l.window / time.Duration(l.limit)
+	remaining, wait, err := l.bucketStore.Take(ctx, key, l.limit, refill, now)
+	if err != nil {
+		return Result{Allowed: true, Limit: l.limit}, fmt.Errorf("Limiter.Allow: %w", err)
+	}
+	return Result{
+		Allowed:    wait == 0,
+		Limit:      l.limit,
+		Remaining:  remaining,
+		Reset:      refill * time.Duration(l.limit-remaining),
+		RetryAfter: wait,
+	}, nil
+}
+
+func (l *Limiter) allowWindow(ctx context.Context, key string, now time.Time) (Result, error) {
+	current := now.UnixNano() / int64(l.window)
+	start := time.Unix(0, current*int64(l.window))
+	expireAt := start.Add(l.window * 2)
+	count, err := l.windowStore.Increment(ctx, key+":"+strconv.FormatInt(current, 10), expireAt)
+	if err != nil {
+		return Result{Allowed: true, Limit: l.limit}, fmt.Errorf("Limiter.Allow: %w", err)
+	}
+	previous, err := l.windowStore.Count(ctx, key+":"+strconv.FormatInt(current-1, 10))
+	if err != nil {
+		return Result{Allowed: true, Limit: l.limit}, fmt.Errorf("Limiter.Allow: %w", err)
+	}
+	elapsed := now.Sub(start)
+	weight := 1 - float64(elapsed)/float64(l.window)
+	used := int(math.Ceil(float64(previous)*weight)) + int(count)
+	res := Result{Allowed: used <= l.limit, Limit: l.limit, Remaining: l.limit - used, Reset: l.window - elapsed}
+	if res.Remaining < 0 {
+		res.Remaining = 0
+	}
+	if !res.Allowed {
+		res.RetryAfter = res.Reset
+	}
+	return res, nil
+}
+
+// KeyByIP keys on the connection address, behind a load balancer use KeyByForwardedIP
+func KeyByIP(r *http.Request) (string, bool) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr, r.RemoteAddr != ""
+	}
+	return host, true
+}
+
+const (
+	HttpHeaderForwardedFor = "X-Forwarded-For"
+	HttpHeaderRealIP       = "X-Real-IP"
+)
+
+// KeyByForwardedIP keys on the client address from X-Forwarded-For or X-Real-IP, the headers are only honoured when the
+// request comes from one of the trusted proxies (IPs or CIDRs) and X-Forwarded-For is walked right to left past trusted hops
+func KeyByForwardedIP(trustedProxies []string) (KeyFunc, error) {
+	trusted := make([]netip.Prefix, 0, len(trustedProxies))
+	for _, proxy := range trustedProxies {
+		prefix, err := netip.ParsePrefix(proxy)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(proxy)
+			if addrErr != nil {
+				return nil, fmt.Errorf("ratelimit.KeyByForwardedIP: invalid trusted proxy %v: %w", proxy, err)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		trusted = append(trusted, prefix.Masked())
+	}
+	isTrusted := func(addr netip.Addr) bool {
+		addr = addr.Unmap()
+		for _, prefix := range trusted {
+			if prefix.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}
+	return func(r *http.Request) (string, bool) {
+		remote, ok := KeyByIP(r)
+		if !ok {
+			return "", false
+		}
+		addr, err := netip.ParseAddr(remote)
+		if err != nil || !isTrusted(addr) {
+			return remote, true
+		}
+		client := addr
+		hops := strings.Split(strings.Join(r.Header.Values(HttpHeaderForwardedFor), ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+			if err != nil {
+				break
+			}
+			client = hop
+			if !isTrusted(hop) {
+				return client.Unmap().String(), true
+			}
+		}
+		if client == addr {
+			if realIP, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get(HttpHeaderRealIP))); err == nil {
+				client = realIP
+			}
+		}
+		return client.Unmap().String(), true
+	}, nil
+}
+
+func KeyByCustomerId(r *http.Request) (string, bool) {
+	customerId := log.GetCustomerIdentifier(r.Context()).CustomerId
+	return customerId, customerId != ""
+}
+
+func KeyByHeader(header string) KeyFunc {
+	return func(r *http.Request) (string, bool) {
+		val := r.Header.Get(header)
+		return val, val != ""
+	}
+}
+
+func KeyByAPIKey(r *http.Request) (string, bool) {
+	return KeyByHeader("x-api-key")(r)
+}
+
+// KeyByRoute keys by the chi route pattern, which is only resolved for limiters mounted on a route with With or inside a Route/Group.
+// Mounted with Use on the root mux it falls back to the request path, so every distinct path gets its own limit
+func KeyByRoute(r *http.Request) (string, bool) {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+		return r.Method + " " + rctx.RoutePattern(), true
+	}
+	return r.Method + " " + r.URL.Path, true
+}
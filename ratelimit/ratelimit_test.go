@@ -0,0 +1,142 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sabariramc/goserverbase/log"
+	"github.com/sabariramc/goserverbase/ratelimit"
+	"gotest.tools/assert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	limiter, err := ratelimit.NewTokenBucketLimiter("bucket", 3, time.Minute, ratelimit.KeyByIP, ratelimit.NewMemoryStore())
+	assert.NilError(t, err)
+	for i := 2; i >= 0; i-- {
+		res, err := limiter.Allow(context.Background(), "k")
+		assert.NilError(t, err)
+		assert.Assert(t, res.Allowed)
+		assert.Equal(t, res.Remaining, i)
+	}
+	res, err := limiter.Allow(context.Background(), "k")
+	assert.NilError(t, err)
+	assert.Assert(t, !res.Allowed)
+	assert.Assert(t, res.RetryAfter > 19*time.Second && res.RetryAfter <= 20*time.Second, res.RetryAfter)
+	res, _ = limiter.Allow(context.Background(), "other")
+	assert.Assert(t, res.Allowed)
+
+	limiter, err = ratelimit.NewTokenBucketLimiter("bucket", 2, time.Millisecond*100, ratelimit.KeyByIP, ratelimit.NewMemoryStore())
+	assert.NilError(t, err)
+	limiter.Allow(context.Background(), "k")
+	limiter.Allow(context.Background(), "k")
+	res, _ = limiter.Allow(context.Background(), "k")
+	assert.Assert(t, !res.Allowed)
+	time.Sleep(time.Millisecond * 60)
+	res, _ = limiter.Allow(context.Background(), "k")
+	assert.Assert(t, res.Allowed)
+}
+
+func TestSlidingWindow(t *testing.T) {
+	limiter, err := ratelimit.NewSlidingWindowLimiter("window", 2, time.Hour, ratelimit.KeyByIP, ratelimit.NewMemoryStore())
+	assert.NilError(t, err)
+	res, err := limiter.Allow(context.Background(), "k")
+	assert.NilError(t, err)
+	assert.Assert(t, res.Allowed)
+	assert.Equal(t, res.Remaining, 1)
+	res, _ = limiter.Allow(context.Background(), "k")
+	assert.Assert(t, res.Allowed)
+	assert.Equal(t, res.Remaining, 0)
+	res, _ = limiter.Allow(context.Background(), "k")
+	assert.Assert(t, !res.Allowed)
+	assert.Equal(t, res.RetryAfter, res.Reset)
+	assert.Assert(t, res.RetryAfter > 0 && res.RetryAfter <= time.Hour)
+}
+
+func TestLimiterValidation(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	_, err := ratelimit.NewTokenBucketLimiter("bucket", 0, time.Minute, ratelimit.KeyByIP, store)
+	assert.Assert(t, errors.Is(err, ratelimit.ErrInvalidLimit), err)
+	_, err = ratelimit.NewSlidingWindowLimiter("window", -1, time.Minute, ratelimit.KeyByIP, store)
+	assert.Assert(t, errors.Is(err, ratelimit.ErrInvalidLimit), err)
+	_, err = ratelimit.NewTokenBucketLimiter("bucket", 10, 5, ratelimit.KeyByIP, store)
+	assert.Assert(t, errors.Is(err, ratelimit.ErrInvalidLimit), err)
+	_, err = ratelimit.NewSlidingWindowLimiter("window", 10, time.Minute, nil, store)
+	assert.Assert(t, errors.Is(err, ratelimit.ErrInvalidLimit), err)
+	_, err = ratelimit.NewTokenBucketLimiter("bucket", 10, time.Minute, ratelimit.KeyByIP, nil)
+	assert.Assert(t, errors.Is(err, ratelimit.ErrInvalidLimit), err)
+}
+
+func TestKeyFuncs(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:5000"
+	key, ok := ratelimit.KeyByIP(req)
+	assert.Assert(t, ok)
+	assert.Equal(t, key, "10.0.0.1")
+	_, ok = ratelimit.KeyByCustomerId(req)
+	assert.Assert(t, !ok)
+	req = req.WithContext(context.WithValue(req.Context(), log.ContextKeyCustomerIdentifier, &log.CustomerIdentifier{CustomerId: "cust-1"}))
+	key, _ = ratelimit.KeyByCustomerId(req)
+	assert.Equal(t, key, "cust-1")
+	req.Header.Set("x-api-key", "api-1")
+	key, _ = ratelimit.KeyByAPIKey(req)
+	assert.Equal(t, key, "api-1")
+	key, ok = ratelimit.KeyByRoute(req)
+	assert.Assert(t, ok)
+	assert.Equal(t, key, "GET /")
+}
+
+func TestKeyByRouteMuxLevel(t *testing.T) {
+	keys := make([]string, 0)
+	router := chi.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, ok := ratelimit.KeyByRoute(r)
+			assert.Assert(t, ok)
+			keys = append(keys, key)
+			next.ServeHTTP(w, r)
+		})
+	})
+	router.With(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, _ := ratelimit.KeyByRoute(r)
+			keys = append(keys, key)
+			next.ServeHTTP(w, r)
+		})
+	}).Post("/tenant/{tenantId}", func(w http.ResponseWriter, r *http.Request) {})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/tenant/t-1", nil))
+	assert.DeepEqual(t, keys, []string{"POST /tenant/t-1", "POST /tenant/{tenantId}"})
+}
+
+func TestKeyByForwardedIP(t *testing.T) {
+	_, err := ratelimit.KeyByForwardedIP([]string{"not-an-ip"})
+	assert.Assert(t, err != nil)
+	keyFunc, err := ratelimit.KeyByForwardedIP([]string{"10.0.0.0/8", "192.168.1.1"})
+	assert.NilError(t, err)
+	for _, tc := range []struct {
+		remote, forwarded, realIP, expected string
+	}{
+		{remote: "203.0.113.9:5000", forwarded: "198.51.100.1", expected: "203.0.113.9"},
+		{remote: "10.0.0.1:5000", forwarded: "198.51.100.1, 203.0.113.7, 192.168.1.1", expected: "203.0.113.7"},
+		{remote: "10.0.0.1:5000", forwarded: "10.0.0.5", expected: "10.0.0.5"},
+		{remote: "192.168.1.1:5000", realIP: "198.51.100.2", expected: "198.51.100.2"},
+		{remote: "10.0.0.1:5000", forwarded: "garbage, 198.51.100.3", expected: "198.51.100.3"},
+		{remote: "10.0.0.1:5000", expected: "10.0.0.1"},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = tc.remote
+		if tc.forwarded != "" {
+			req.Header.Set("X-Forwarded-For", tc.forwarded)
+		}
+		if tc.realIP != "" {
+			req.Header.Set("X-Real-IP", tc.realIP)
+		}
+		key, ok := keyFunc(req)
+		assert.Assert(t, ok)
+		assert.Equal(t, key, tc.expected, tc)
+	}
+}
@@ -0,0 +1,141 @@
+package trace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const OTLPTracePath = "/v1/traces"
+
+type OTLPExporter struct {
+	url        string
+	headers    map[string]string
+	httpClient *http.Client
+}
+
+func NewOTLPExporter(endpoint string, headers map[string]string, timeout time.Duration) *OTLPExporter {
+	url := strings.TrimSuffix(endpoint, "/")
+	if !strings.HasSuffix(url, OTLPTracePath) {
+		url += OTLPTracePath
+	}
+	return &OTLPExporter{url: url, headers: headers, httpClient: &http.Client{Timeout: timeout}}
+}
+
+type otlpKeyValue struct {
+	Key   string         `json:"key"`
+	Value map[string]any `json:"value"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	TraceState        string         `json:"traceState,omitempty"`
+	Name              string         `json:"name"`
+	Kind              SpanKind       `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            map[string]any `json:"status"`
+}
+
+func (e *OTLPExporter) ExportSpans(ctx context.Context, spans []*SpanData) error {
+	blob, err := json.Marshal(NewOTLPPayload(spans))
+	if err != nil {
+		return fmt.Errorf("OTLPExporter.ExportSpans: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(blob))
+	if err != nil {
+		return fmt.Errorf("OTLPExporter.ExportSpans: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+	res, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("OTLPExporter.ExportSpans: %w", err)
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+	if res.StatusCode > 299 {
+		return fmt.Errorf("OTLPExporter.ExportSpans: unexpected status code %v", res.StatusCode)
+	}
+	return nil
+}
+
+func (e *OTLPExporter) Shutdown(ctx context.Context) error {
+	e.httpClient.CloseIdleConnections()
+	return nil
+}
+
+func NewOTLPPayload(spans []*SpanData) map[string]any {
+	byService := make(map[string][]otlpSpan)
+	order := make([]string, 0)
+	for _, span := range spans {
+		if _, ok := byService[span.ServiceName]; !ok {
+			order = append(order, span.ServiceName)
+		}
+		byService[span.ServiceName] = append(byService[span.ServiceName], toOTLPSpan(span))
+	}
+	resourceSpans := make([]map[string]any, 0, len(order))
+	for _, serviceName := range order {
+		resourceSpans = append(resourceSpans, map[string]any{
+			"resource": map[string]any{"attributes": []otlpKeyValue{toOTLPKeyValue(String("service.name", serviceName))}},
+			"scopeSpans": []map[string]any{{
+				"scope": map[string]any{"name": "github.com/sabariramc/goserverbase/trace"},
+				"spans": byService[serviceName],
+			}},
+		})
+	}
+	return map[string]any{"resourceSpans": resourceSpans}
+}
+
+func toOTLPSpan(span *SpanData) otlpSpan {
+	res := otlpSpan{
+		TraceID:           span.SpanContext.TraceID.String(),
+		SpanID:            span.SpanContext.SpanID.String(),
+		TraceState:        span.SpanContext.TraceState,
+		Name:              span.Name,
+		Kind:              span.Kind,
+		StartTimeUnixNano: strconv.FormatInt(span.StartTime.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.EndTime.UnixNano(), 10),
+		Status:            map[string]any{"code": span.StatusCode},
+	}
+	if span.ParentSpanID.IsValid() {
+		res.ParentSpanID = span.ParentSpanID.String()
+	}
+	if span.StatusMessage != "" {
+		res.Status["message"] = span.StatusMessage
+	}
+	for _, attr := range span.Attributes {
+		res.Attributes = append(res.Attributes, toOTLPKeyValue(attr))
+	}
+	return res
+}
+
+func toOTLPKeyValue(attr Attribute) otlpKeyValue {
+	var value map[string]any
+	switch v := attr.Value.(type) {
+	case string:
+		value = map[string]any{"stringValue": v}
+	case bool:
+		value = map[string]any{"boolValue": v}
+	case int64:
+		value = map[string]any{"intValue": strconv.FormatInt(v, 10)}
+	case int:
+		value = map[string]any{"intValue": strconv.Itoa(v)}
+	case float64:
+		value = map[string]any{"doubleValue": v}
+	default:
+		value = map[string]any{"stringValue": fmt.Sprint(v)}
+	}
+	return otlpKeyValue{Key: attr.Key, Value: value}
+}
@@ -0,0 +1,181 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+type SpanExporter interface {
+	ExportSpans(ctx context.Context, spans []*SpanData) error
+	Shutdown(ctx context.Context) error
+}
+
+type SimpleSpanProcessor struct {
+	exporter SpanExporter
+}
+
+func NewSimpleSpanProcessor(exporter SpanExporter) *SimpleSpanProcessor {
+	return &SimpleSpanProcessor{exporter: exporter}
+}
+
+func (p *SimpleSpanProcessor) OnEnd(span *SpanData) {
+	if err := p.exporter.ExportSpans(context.Background(), []*SpanData{span}); err != nil {
+		fmt.Fprintf(os.Stderr, "trace: span export failed: %v\n", err)
+	}
+}
+
+func (p *SimpleSpanProcessor) Shutdown(ctx context.Context) error {
+	return p.exporter.Shutdown(ctx)
+}
+
+const (
+	DefaultBatchSize     = 512
+	DefaultQueueSize     = 2048
+	DefaultBatchInterval = time.Second * 5
+)
+
+type BatchSpanProcessor struct {
+	exporter  SpanExporter
+	batchSize int
+	interval  time.Duration
+	queue     chan *SpanData
+	flush     chan chan struct{}
+	done      chan struct{}
+	lock      sync.RWMutex
+	closed    bool
+}
+
+func NewBatchSpanProcessor(exporter SpanExporter, batchSize int, interval time.Duration) *BatchSpanProcessor {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	if interval <= 0 {
+		interval = DefaultBatchInterval
+	}
+	p := &BatchSpanProcessor{
+		exporter:  exporter,
+		batchSize: batchSize,
+		interval:  interval,
+		queue:     make(chan *SpanData, DefaultQueueSize),
+		flush:     make(chan chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go p.start()
+	return p
+}
+
+func (p *BatchSpanProcessor) OnEnd(span *SpanData) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.closed {
+		return
+	}
+	select {
+	case p.queue <- span:
+	default:
+	}
+}
+
+func (p *BatchSpanProcessor) start() {
+	defer close(p.done)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	batch := make([]*SpanData, 0, p.batchSize)
+	export := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := p.exporter.ExportSpans(context.Background(), batch); err != nil {
+			fmt.Fprintf(os.Stderr, "trace: span export failed: %v\n", err)
+		}
+		batch = make([]*SpanData, 0, p.batchSize)
+	}
+	for {
+		select {
+		case span, ok := <-p.queue:
+			if !ok {
+				export()
+				return
+			}
+			batch = append(batch, span)
+			if len(batch) >= p.batchSize {
+				export()
+			}
+		case ack := <-p.flush:
+			for len(p.queue) > 0 {
+				batch = append(batch, <-p.queue)
+			}
+			export()
+			close(ack)
+		case <-ticker.C:
+			export()
+		}
+	}
+}
+
+func (p *BatchSpanProcessor) ForceFlush(ctx context.Context) error {
+	ack := make(chan struct{})
+	select {
+	case p.flush <- ack:
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("BatchSpanProcessor.ForceFlush: %w", ctx.Err())
+	}
+	select {
+	case <-ack:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("BatchSpanProcessor.ForceFlush: %w", ctx.Err())
+	}
+}
+
+func (p *BatchSpanProcessor) Shutdown(ctx context.Context) error {
+	p.lock.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.lock.Unlock()
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		return fmt.Errorf("BatchSpanProcessor.Shutdown: %w", ctx.Err())
+	}
+	return p.exporter.Shutdown(ctx)
+}
+
+type InMemoryExporter struct {
+	lock  sync.Mutex
+	spans []*SpanData
+}
+
+func NewInMemoryExporter() *InMemoryExporter {
+	return &InMemoryExporter{spans: make([]*SpanData, 0)}
+}
+
+func (e *InMemoryExporter) ExportSpans(ctx context.Context, spans []*SpanData) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *InMemoryExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (e *InMemoryExporter) GetSpans() []*SpanData {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return append([]*SpanData(nil), e.spans...)
+}
+
+func (e *InMemoryExporter) Reset() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans = make([]*SpanData, 0)
+}
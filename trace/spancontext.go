@@ -0,0 +1,185 @@
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	HeaderTraceParent = "traceparent"
+	HeaderTraceState  = "tracestate"
+	FlagSampled       = byte(0x01)
+)
+
+var ErrInvalidTraceParent = fmt.Errorf("invalid traceparent")
+
+type TraceID [16]byte
+
+type SpanID [8]byte
+
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+func (t TraceID) IsValid() bool {
+	return t != TraceID{}
+}
+
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+func (s SpanID) IsValid() bool {
+	return s != SpanID{}
+}
+
+func newTraceID() TraceID {
+	var t TraceID
+	for !t.IsValid() {
+		rand.Read(t[:])
+	}
+	return t
+}
+
+func newSpanID() SpanID {
+	var s SpanID
+	for !s.IsValid() {
+		rand.Read(s[:])
+	}
+	return s
+}
+
+type SpanContext struct {
+	TraceID    TraceID
+	SpanID     SpanID
+	TraceFlags byte
+	TraceState string
+	Remote     bool
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+func (sc SpanContext) IsSampled() bool {
+	return sc.TraceFlags&FlagSampled == FlagSampled
+}
+
+func (sc SpanContext) TraceParent() string {
+	return fmt.Sprintf("00-%v-%v-%02x", sc.TraceID, sc.SpanID, sc.TraceFlags)
+}
+
+func ParseTraceParent(val string) (SpanContext, error) {
+	var sc SpanContext
+	parts := strings.Split(strings.TrimSpace(val), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return sc, ErrInvalidTraceParent
+	}
+	if len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return sc, ErrInvalidTraceParent
+	}
+	if _, err := hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return sc, ErrInvalidTraceParent
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return sc, ErrInvalidTraceParent
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return sc, ErrInvalidTraceParent
+	}
+	sc.TraceFlags = flags[0]
+	if !sc.IsValid() {
+		return sc, ErrInvalidTraceParent
+	}
+	sc.Remote = true
+	return sc, nil
+}
+
+type Carrier interface {
+	Get(key string) string
+	Set(key, value string)
+}
+
+type MapCarrier map[string]string
+
+func (c MapCarrier) Get(key string) string {
+	return c[key]
+}
+
+func (c MapCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+type HeaderCarrier http.Header
+
+func (c HeaderCarrier) Get(key string) string {
+	return http.Header(c).Get(key)
+}
+
+func (c HeaderCarrier) Set(key, value string) {
+	http.Header(c).Set(key, value)
+}
+
+func Inject(ctx context.Context, carrier Carrier) {
+	sc := SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	carrier.Set(HeaderTraceParent, sc.TraceParent())
+	if sc.TraceState != "" {
+		carrier.Set(HeaderTraceState, sc.TraceState)
+	}
+}
+
+func Extract(ctx context.Context, carrier Carrier) context.Context {
+	sc, err := ParseTraceParent(carrier.Get(HeaderTraceParent))
+	if err != nil {
+		return ctx
+	}
+	sc.TraceState = carrier.Get(HeaderTraceState)
+	return ContextWithRemoteSpanContext(ctx, sc)
+}
+
+type contextKey string
+
+const (
+	contextKeySpan              contextKey = "traceSpan"
+	contextKeyRemoteSpanContext contextKey = "traceRemoteSpanContext"
+)
+
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	return context.WithValue(ctx, contextKeySpan, span)
+}
+
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	if SpanFromContext(ctx) != nil {
+		ctx = context.WithValue(ctx, contextKeySpan, (*Span)(nil))
+	}
+	return context.WithValue(ctx, contextKeyRemoteSpanContext, sc)
+}
+
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(contextKeySpan).(*Span)
+	return span
+}
+
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.SpanContext()
+	}
+	sc, _ := ctx.Value(contextKeyRemoteSpanContext).(SpanContext)
+	return sc
+}
+
+func GetTraceID(ctx context.Context) string {
+	sc := SpanContextFromContext(ctx)
+	if !sc.TraceID.IsValid() {
+		return ""
+	}
+	return sc.TraceID.String()
+}
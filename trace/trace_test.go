@@ -0,0 +1,83 @@
+package trace_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sabariramc/goserverbase/trace"
+	"gotest.tools/assert"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	sc, err := trace.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.NilError(t, err)
+	assert.Equal(t, sc.TraceID.String(), "4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.Equal(t, sc.SpanID.String(), "00f067aa0ba902b7")
+	assert.Assert(t, sc.IsSampled())
+	assert.Assert(t, sc.Remote)
+	assert.Equal(t, sc.TraceParent(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	for _, val := range []string{
+		"",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"00-4bf92f3577b34da6a3ce929d0e0e473g-00f067aa0ba902b7-01",
+	} {
+		_, err := trace.ParseTraceParent(val)
+		assert.Equal(t, err, trace.ErrInvalidTraceParent, val)
+	}
+}
+
+func TestPropagation(t *testing.T) {
+	exporter := trace.NewInMemoryExporter()
+	trace.SetTracer(trace.NewTracer("test", trace.NewSimpleSpanProcessor(exporter)))
+	defer trace.SetTracer(trace.NewTracer("", nil))
+	header := http.Header{}
+	header.Set(trace.HeaderTraceParent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	header.Set(trace.HeaderTraceState, "vendor=value")
+	ctx := trace.Extract(context.Background(), trace.HeaderCarrier(header))
+	assert.Equal(t, trace.GetTraceID(ctx), "4bf92f3577b34da6a3ce929d0e0e4736")
+	ctx, parent := trace.Start(ctx, "parent", trace.SpanKindServer)
+	_, child := trace.Start(ctx, "child", trace.SpanKindProducer, trace.String("key", "value"))
+	carrier := trace.MapCarrier{}
+	trace.Inject(trace.ContextWithSpan(ctx, child), carrier)
+	assert.Equal(t, carrier[trace.HeaderTraceParent], child.SpanContext().TraceParent())
+	assert.Equal(t, carrier[trace.HeaderTraceState], "vendor=value")
+	child.End()
+	parent.End()
+	spans := exporter.GetSpans()
+	assert.Equal(t, len(spans), 2)
+	assert.Equal(t, spans[0].Name, "child")
+	assert.Equal(t, spans[0].ParentSpanID, parent.SpanContext().SpanID)
+	assert.Equal(t, spans[1].ParentSpanID.String(), "00f067aa0ba902b7")
+	assert.Equal(t, spans[1].SpanContext.TraceID.String(), "4bf92f3577b34da6a3ce929d0e0e4736")
+}
+
+func TestOTLPExporter(t *testing.T) {
+	var payload map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, trace.OTLPTracePath)
+		body, _ := io.ReadAll(r.Body)
+		assert.NilError(t, json.Unmarshal(body, &payload))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	processor := trace.NewBatchSpanProcessor(trace.NewOTLPExporter(srv.URL, nil, time.Second), 10, time.Minute)
+	tr := trace.NewTracer("test", processor)
+	_, span := tr.Start(context.Background(), "op", trace.SpanKindInternal)
+	span.End()
+	assert.NilError(t, processor.ForceFlush(context.Background()))
+	assert.NilError(t, tr.Shutdown(context.Background()))
+	resourceSpans := payload["resourceSpans"].([]any)
+	assert.Equal(t, len(resourceSpans), 1)
+	scopeSpans := resourceSpans[0].(map[string]any)["scopeSpans"].([]any)
+	spans := scopeSpans[0].(map[string]any)["spans"].([]any)
+	assert.Equal(t, spans[0].(map[string]any)["name"], "op")
+	assert.Equal(t, spans[0].(map[string]any)["traceId"], span.SpanContext().TraceID.String())
+}
@@ -0,0 +1,75 @@
+package trace
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+type SpanProcessor interface {
+	OnEnd(span *SpanData)
+	Shutdown(ctx context.Context) error
+}
+
+type Tracer struct {
+	serviceName string
+	processor   SpanProcessor
+}
+
+var globalTracer atomic.Pointer[Tracer]
+
+func init() {
+	globalTracer.Store(NewTracer("", nil))
+}
+
+func NewTracer(serviceName string, processor SpanProcessor) *Tracer {
+	return &Tracer{serviceName: serviceName, processor: processor}
+}
+
+func SetTracer(t *Tracer) {
+	globalTracer.Store(t)
+}
+
+func GetTracer() *Tracer {
+	return globalTracer.Load()
+}
+
+func Start(ctx context.Context, name string, kind SpanKind, attributes ...Attribute) (context.Context, *Span) {
+	return GetTracer().Start(ctx, name, kind, attributes...)
+}
+
+func (t *Tracer) Start(ctx context.Context, name string, kind SpanKind, attributes ...Attribute) (context.Context, *Span) {
+	parent := SpanContextFromContext(ctx)
+	sc := SpanContext{SpanID: newSpanID(), TraceFlags: FlagSampled}
+	if parent.IsValid() {
+		sc.TraceID = parent.TraceID
+		sc.TraceFlags = parent.TraceFlags
+		sc.TraceState = parent.TraceState
+	} else {
+		sc.TraceID = newTraceID()
+	}
+	span := &Span{
+		tracer: t,
+		data: SpanData{
+			Name:         name,
+			Kind:         kind,
+			SpanContext:  sc,
+			ParentSpanID: parent.SpanID,
+			StartTime:    time.Now(),
+			Attributes:   append([]Attribute(nil), attributes...),
+			ServiceName:  t.serviceName,
+		},
+	}
+	return ContextWithSpan(ctx, span), span
+}
+
+func (t *Tracer) Name() string {
+	return "Tracer"
+}
+
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	if t.processor == nil {
+		return nil
+	}
+	return t.processor.Shutdown(ctx)
+}